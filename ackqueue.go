@@ -0,0 +1,214 @@
+package ringbuffer
+
+import (
+	"time"
+)
+
+// Element leased from an AckQueue.
+type Delivery[T any] struct {
+	ID      uint64 // lease identifier for Ack and Nack
+	Value   T
+	Attempt int // 1 for the first delivery, incremented on each redelivery
+}
+
+type ackEntry[T any] struct {
+	v        T
+	attempts int
+}
+
+type ackLease[T any] struct {
+	ackEntry[T]
+	deadline time.Time
+}
+
+// Counters of elements which exhausted their delivery attempts.
+type DeadLetterStats struct {
+	Moved   uint64 // moved to the dead-letter buffer
+	Dropped uint64 // discarded because the dead-letter buffer was full
+}
+
+// Fixed length FIFO queue with acknowledged consumption.
+//
+// Pop leases the oldest element instead of removing it. A leased element must be acknowledged with Ack. Nack, or
+// lease expiry if a lease duration is set, returns the element to the front of the queue for redelivery. Leased
+// elements count against capacity, so that returning them never fails.
+//
+// Optionally, elements failing too many deliveries are moved to a dead-letter buffer instead, see SetDeadLetter.
+type AckQueue[T any] struct {
+	ready       RingBuffer[ackEntry[T]]
+	leases      map[uint64]ackLease[T]
+	order       RingBuffer[uint64] // lease IDs in the order of issue, which is also the order of deadlines
+	nextID      uint64
+	ttl         time.Duration
+	clock       Clock
+	dead        *RingBuffer[T]
+	maxAttempts int
+	deadStats   DeadLetterStats
+}
+
+// Create a new queue which can store capacity elements, leased ones included.
+//
+// Leases expire after leaseDuration, zero means leases never expire.
+func NewAckQueue[T any](capacity int, leaseDuration time.Duration) AckQueue[T] {
+	return AckQueue[T]{
+		ready:  New[ackEntry[T]](capacity),
+		leases: make(map[uint64]ackLease[T]),
+		order:  New[uint64](capacity),
+		ttl:    leaseDuration,
+	}
+}
+
+// Use the given clock instead of the system clock. Nil restores the system clock.
+func (q *AckQueue[T]) SetClock(c Clock) {
+	q.clock = c
+}
+
+// Move elements to dst once they were rejected or expired after maxAttempts deliveries. Nil dst disables it.
+//
+// If dst is full, such elements are discarded. Both cases are counted, see DeadLetterStats.
+func (q *AckQueue[T]) SetDeadLetter(dst *RingBuffer[T], maxAttempts int) {
+	q.dead = dst
+	q.maxAttempts = maxAttempts
+}
+
+// Get the dead-letter buffer, nil if not set.
+func (q AckQueue[T]) DeadLetter() *RingBuffer[T] {
+	return q.dead
+}
+
+// Get counters of dead-lettered elements.
+func (q AckQueue[T]) DeadLetterStats() DeadLetterStats {
+	return q.deadStats
+}
+
+// Move an element to the dead-letter buffer if it has no delivery attempts left.
+//
+// Returns true if the element was taken out of circulation.
+func (q *AckQueue[T]) bury(e ackEntry[T]) bool {
+	if q.dead == nil || e.attempts < q.maxAttempts {
+		return false
+	}
+	if q.dead.Push(e.v) {
+		q.deadStats.Moved++
+	} else {
+		q.deadStats.Dropped++
+	}
+	return true
+}
+
+// How many elements a queue can store?
+func (q AckQueue[T]) Cap() int {
+	return q.ready.Cap()
+}
+
+// How many elements are waiting for delivery?
+func (q AckQueue[T]) Len() int {
+	return q.ready.Len()
+}
+
+// How many elements are leased and not acknowledged yet?
+func (q AckQueue[T]) InFlight() int {
+	return len(q.leases)
+}
+
+// Push a new element to the queue.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (q *AckQueue[T]) Push(v T) bool {
+	if q.ready.Len()+len(q.leases) >= q.ready.Cap() {
+		return false
+	}
+	return q.ready.Push(ackEntry[T]{v: v})
+}
+
+// Lease the oldest element waiting for delivery. Expired leases are returned to the queue first.
+//
+// Returns the delivery and true on success. Returns default value and false if there were no elements waiting.
+func (q *AckQueue[T]) Pop() (Delivery[T], bool) {
+	q.Expire()
+	e, ok := q.ready.Pop()
+	if !ok {
+		return Delivery[T]{}, false
+	}
+	e.attempts++
+	q.nextID++
+	id := q.nextID
+	l := ackLease[T]{ackEntry: e}
+	if q.ttl > 0 {
+		l.deadline = clockNow(q.clock).Add(q.ttl)
+		if !q.order.Push(id) {
+			q.compactOrder()
+			q.order.Push(id)
+		}
+	}
+	q.leases[id] = l
+	return Delivery[T]{ID: id, Value: e.v, Attempt: e.attempts}, true
+}
+
+// Acknowledge a delivery, removing its element from the queue for good.
+//
+// Returns false if the lease is unknown, e.g. because it already expired.
+func (q *AckQueue[T]) Ack(id uint64) bool {
+	if _, ok := q.leases[id]; !ok {
+		return false
+	}
+	delete(q.leases, id)
+	return true
+}
+
+// Reject a delivery, returning its element to the front of the queue or moving it to the dead-letter buffer.
+//
+// Returns false if the lease is unknown, e.g. because it already expired.
+func (q *AckQueue[T]) Nack(id uint64) bool {
+	l, ok := q.leases[id]
+	if !ok {
+		return false
+	}
+	delete(q.leases, id)
+	if !q.bury(l.ackEntry) {
+		q.ready.insert(0, l.ackEntry)
+	}
+	return true
+}
+
+// Return elements with expired leases to the front of the queue, keeping their original order. Elements without
+// delivery attempts left are moved to the dead-letter buffer instead.
+//
+// Called by Pop automatically. Returns how many leases expired.
+func (q *AckQueue[T]) Expire() int {
+	if q.ttl <= 0 {
+		return 0
+	}
+	now := clockNow(q.clock)
+	n := 0
+	var expired []ackEntry[T]
+	for q.order.Len() > 0 {
+		id := *q.order.slot(0)
+		l, ok := q.leases[id]
+		if ok && l.deadline.After(now) {
+			break
+		}
+		q.order.Pop()
+		if ok {
+			delete(q.leases, id)
+			n++
+			if !q.bury(l.ackEntry) {
+				expired = append(expired, l.ackEntry)
+			}
+		}
+	}
+	for i := len(expired) - 1; i >= 0; i-- {
+		q.ready.insert(0, expired[i])
+	}
+	return n
+}
+
+// Drop IDs of leases which were already acknowledged or rejected.
+func (q *AckQueue[T]) compactOrder() {
+	c := q.order.Cursor()
+	for c.Next() {
+		if _, ok := q.leases[c.Value()]; !ok {
+			c.Delete()
+		}
+	}
+}
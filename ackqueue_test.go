@@ -0,0 +1,160 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAckQueue(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var q ringbuffer.AckQueue[int]
+		assert.Equal(false, q.Push(1))
+		_, ok := q.Pop()
+		assert.Equal(false, ok)
+		assert.Equal(false, q.Ack(1))
+	}
+
+	q := ringbuffer.NewAckQueue[string](3, 0)
+	assert.Equal(3, q.Cap())
+	assert.Equal(true, q.Push("a"))
+	assert.Equal(true, q.Push("b"))
+	assert.Equal(true, q.Push("c"))
+
+	d1, ok := q.Pop()
+	assert.Equal(true, ok)
+	assert.Equal("a", d1.Value)
+	assert.Equal(1, d1.Attempt)
+	assert.Equal(2, q.Len())
+	assert.Equal(1, q.InFlight())
+
+	// leased elements still occupy space
+	assert.Equal(false, q.Push("d"))
+	assert.Equal(true, q.Ack(d1.ID))
+	assert.Equal(false, q.Ack(d1.ID))
+	assert.Equal(true, q.Push("d"))
+
+	// nack returns the element to the front
+	d2, _ := q.Pop()
+	assert.Equal("b", d2.Value)
+	assert.Equal(true, q.Nack(d2.ID))
+	assert.Equal(false, q.Nack(d2.ID))
+	d3, _ := q.Pop()
+	assert.Equal("b", d3.Value)
+	assert.Equal(2, d3.Attempt)
+	assert.NotEqual(d2.ID, d3.ID)
+}
+
+func TestAckQueueExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := ringbuffer.NewManualClock(time.Unix(1000, 0))
+	q := ringbuffer.NewAckQueue[int](4, time.Second)
+	q.SetClock(clock)
+	for i := 1; i <= 4; i++ {
+		q.Push(i)
+	}
+	d1, _ := q.Pop()
+	d2, _ := q.Pop()
+	clock.Advance(500 * time.Millisecond)
+	d3, _ := q.Pop()
+	assert.Equal(true, q.Ack(d2.ID))
+	assert.Equal(0, q.Expire())
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(1, q.Expire())
+	assert.Equal(false, q.Ack(d1.ID))
+	assert.Equal(2, q.Len())
+
+	// each expired lease goes to the front when it is noticed
+	clock.Advance(time.Second)
+	var got []int
+	for {
+		d, ok := q.Pop()
+		if !ok {
+			break
+		}
+		got = append(got, d.Value)
+		q.Ack(d.ID)
+	}
+	assert.Equal([]int{3, 1, 4}, got)
+	assert.Equal(false, q.Nack(d3.ID))
+
+	// leases expiring together keep their order
+	q.Push(5)
+	q.Push(6)
+	q.Pop()
+	q.Pop()
+	clock.Advance(time.Second)
+	d5, _ := q.Pop()
+	assert.Equal(5, d5.Value)
+	assert.Equal(2, d5.Attempt)
+	q.Ack(d5.ID)
+	d6, _ := q.Pop()
+	assert.Equal(6, d6.Value)
+	q.Ack(d6.ID)
+
+	// many acknowledged leases don't exhaust lease bookkeeping
+	for i := 0; i < 100; i++ {
+		assert.Equal(true, q.Push(i))
+		d, ok := q.Pop()
+		assert.Equal(true, ok)
+		assert.Equal(i, d.Value)
+		assert.Equal(true, q.Ack(d.ID))
+	}
+	assert.Equal(0, q.InFlight())
+}
+
+func TestAckQueueDeadLetter(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := ringbuffer.NewManualClock(time.Unix(1000, 0))
+	dead := ringbuffer.New[string](1)
+	q := ringbuffer.NewAckQueue[string](5, time.Second)
+	q.SetClock(clock)
+	q.SetDeadLetter(&dead, 2)
+	assert.Equal(&dead, q.DeadLetter())
+	q.Push("poison")
+	q.Push("expiring")
+	q.Push("overflow")
+
+	d, _ := q.Pop()
+	q.Nack(d.ID)
+	d, _ = q.Pop()
+	assert.Equal("poison", d.Value)
+	assert.Equal(2, d.Attempt)
+	q.Nack(d.ID)
+	assert.Equal(ringbuffer.DeadLetterStats{Moved: 1}, q.DeadLetterStats())
+	assert.Equal([]string{"poison"}, contents(&dead))
+
+	// expiry counts as a failed attempt too, dead-letter buffer is full now
+	for i := 0; i < 2; i++ {
+		d, _ = q.Pop()
+		assert.Equal("expiring", d.Value)
+		clock.Advance(time.Second)
+		assert.Equal(1, q.Expire())
+	}
+	assert.Equal(ringbuffer.DeadLetterStats{Moved: 1, Dropped: 1}, q.DeadLetterStats())
+	assert.Equal(1, q.Len())
+	assert.Equal(0, q.InFlight())
+}
+
+func ExampleAckQueue() {
+	q := ringbuffer.NewAckQueue[string](10, 0)
+	q.Push("job")
+	d, _ := q.Pop()
+	fmt.Println(d.Value, d.Attempt)
+	q.Nack(d.ID) // processing failed, try again
+	d, _ = q.Pop()
+	fmt.Println(d.Value, d.Attempt)
+	q.Ack(d.ID)
+	fmt.Println(q.Len(), q.InFlight())
+	// Output:
+	// job 1
+	// job 2
+	// 0 0
+}
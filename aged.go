@@ -0,0 +1,58 @@
+package ringbuffer
+
+import (
+	"time"
+)
+
+// Fixed length FIFO ring buffer which timestamps elements on push.
+//
+// Useful for alerting on elements stuck in a queue for too long, rather than on queue depth alone.
+type Aged[T any] struct {
+	buf   Tagged[T, time.Time]
+	clock Clock
+}
+
+// Create a new buffer which can store capacity elements.
+func NewAged[T any](capacity int) Aged[T] {
+	return Aged[T]{
+		buf: NewTagged[T, time.Time](capacity),
+	}
+}
+
+// Use the given clock instead of the system clock. Nil restores the system clock.
+func (b *Aged[T]) SetClock(c Clock) {
+	b.clock = c
+}
+
+// How many elements a buffer can store?
+func (b Aged[T]) Cap() int {
+	return b.buf.Cap()
+}
+
+// How many elements are currently stored in the buffer?
+func (b Aged[T]) Len() int {
+	return b.buf.Len()
+}
+
+// How long ago was the oldest stored element pushed? Returns 0 if the buffer is empty.
+func (b Aged[T]) OldestAge() time.Duration {
+	if b.buf.Len() == 0 {
+		return 0
+	}
+	return clockNow(b.clock).Sub(*b.buf.meta.slot(0))
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *Aged[T]) Push(v T) bool {
+	return b.buf.Push(v, clockNow(b.clock))
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *Aged[T]) Pop() (T, bool) {
+	v, _, ok := b.buf.Pop()
+	return v, ok
+}
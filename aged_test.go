@@ -0,0 +1,51 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAged(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var b ringbuffer.Aged[int]
+		assert.Equal(false, b.Push(1))
+		assert.Equal(time.Duration(0), b.OldestAge())
+	}
+
+	clock := ringbuffer.NewManualClock(time.Unix(1000, 0))
+	b := ringbuffer.NewAged[int](2)
+	b.SetClock(clock)
+	assert.Equal(2, b.Cap())
+	assert.Equal(time.Duration(0), b.OldestAge())
+
+	b.Push(1)
+	clock.Advance(time.Second)
+	b.Push(2)
+	assert.Equal(false, b.Push(3))
+	clock.Advance(time.Second)
+	assert.Equal(2*time.Second, b.OldestAge())
+
+	v, _ := b.Pop()
+	assert.Equal(1, v)
+	assert.Equal(time.Second, b.OldestAge())
+	b.Pop()
+	assert.Equal(time.Duration(0), b.OldestAge())
+	assert.Equal(0, b.Len())
+}
+
+func ExampleAged() {
+	clock := ringbuffer.NewManualClock(time.Unix(1000, 0))
+	b := ringbuffer.NewAged[string](10)
+	b.SetClock(clock)
+	b.Push("job")
+	clock.Advance(90 * time.Second)
+	if b.OldestAge() > time.Minute {
+		fmt.Println("stuck for", b.OldestAge())
+	}
+	// Output: stuck for 1m30s
+}
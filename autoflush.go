@@ -0,0 +1,183 @@
+package ringbuffer
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Bounded asynchronous writer created by Bytes.AutoFlush, writing buffered data to an underlying writer in a
+// background goroutine.
+//
+// Writes complete as soon as the data is buffered and only wait when the buffer is full. It is safe for concurrent use.
+type AutoFlusher struct {
+	mu       sync.Mutex
+	drained  sync.Cond // broadcast after data was written to w or on failure
+	buf      *Bytes
+	w        io.Writer
+	interval time.Duration
+	lowWater int
+	clock    Clock
+	tick     <-chan time.Time // next scheduled flush, nil without interval
+	in, out  uint64           // bytes buffered and written to w so far
+	err      error            // first error of w
+	closed   bool
+	kick     chan struct{} // flush now
+	rearm    chan struct{} // tick was replaced
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Start writing buffered data to w in the background, the buffer must not be used directly from now on.
+//
+// Data is written once at least lowWater bytes are buffered or the buffer is full, and every interval otherwise.
+// Non-positive interval disables the schedule, lowWater below 1 writes as soon as anything is buffered. Call Close to
+// write out the rest and end the background goroutine.
+func (b *Bytes) AutoFlush(w io.Writer, interval time.Duration, lowWater int) *AutoFlusher {
+	f := &AutoFlusher{
+		buf:      b,
+		w:        w,
+		interval: interval,
+		lowWater: min(max(lowWater, 1), max(b.Cap(), 1)),
+		kick:     make(chan struct{}, 1),
+		rearm:    make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	f.drained.L = &f.mu
+	f.arm()
+	go f.run()
+	return f
+}
+
+// Use the given clock instead of the system clock. Nil restores the system clock. The schedule restarts from now.
+func (f *AutoFlusher) SetClock(c Clock) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.clock = c
+	f.arm()
+	signal(f.rearm)
+}
+
+// How many bytes are buffered and not yet written to the underlying writer?
+func (f *AutoFlusher) Buffered() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.buf.Len()
+}
+
+// Write data to the buffer, waiting for the background goroutine whenever the buffer is full.
+//
+// Returns the first error of the underlying writer, after writing as much as was buffered before. Returns ErrClosed
+// after Close, or ErrFull if the buffer has zero capacity.
+func (f *AutoFlusher) Write(data []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for {
+		if f.closed {
+			return n, ErrClosed
+		}
+		if f.err != nil {
+			return n, f.err
+		}
+		if n == len(data) {
+			return n, nil
+		}
+		if f.buf.Cap() == 0 {
+			return n, ErrFull
+		}
+		if f.buf.Free() > 0 {
+			m, _ := f.buf.Write(data[n:])
+			n += m
+			f.in += uint64(m)
+			if f.buf.Len() >= f.lowWater {
+				signal(f.kick)
+			}
+			continue
+		}
+		f.drained.Wait()
+	}
+}
+
+// Wait until all data buffered before the call is written to the underlying writer.
+//
+// Returns the first error of the underlying writer.
+func (f *AutoFlusher) Flush() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	target := f.in
+	signal(f.kick)
+	for f.err == nil && f.out < target {
+		f.drained.Wait()
+	}
+	return f.err
+}
+
+// Write out all buffered data and end the background goroutine. Subsequent writes return ErrClosed.
+//
+// Returns the first error of the underlying writer. Closing a closed writer does nothing.
+func (f *AutoFlusher) Close() error {
+	f.mu.Lock()
+	if !f.closed {
+		f.closed = true
+		close(f.stop)
+		f.drained.Broadcast()
+	}
+	f.mu.Unlock()
+	<-f.done
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
+
+// Schedule the next flush one interval from now. The lock must be held.
+func (f *AutoFlusher) arm() {
+	if f.interval > 0 {
+		f.tick = clockAfter(f.clock, f.interval)
+	}
+}
+
+func (f *AutoFlusher) run() {
+	defer close(f.done)
+	for {
+		f.mu.Lock()
+		tick := f.tick
+		f.mu.Unlock()
+		select {
+		case <-f.rearm:
+			continue
+		case <-f.kick:
+		case <-tick:
+			f.mu.Lock()
+			if f.tick == tick {
+				f.arm()
+			}
+			f.mu.Unlock()
+		case <-f.stop:
+			f.flush()
+			return
+		}
+		f.flush()
+	}
+}
+
+// Write all buffered data to w. The lock is released while w is writing, the region being written to w is not
+// touched by writes to the buffer meanwhile.
+func (f *AutoFlusher) flush() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for f.err == nil && f.buf.Len() > 0 {
+		x, _ := f.buf.ReadSlices()
+		f.mu.Unlock()
+		n, err := f.w.Write(x)
+		f.mu.Lock()
+		if err == nil && n < len(x) {
+			err = io.ErrShortWrite
+		}
+		f.buf.Consume(n)
+		f.out += uint64(n)
+		f.err = err
+		f.drained.Broadcast()
+	}
+}
@@ -0,0 +1,134 @@
+package ringbuffer_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Writer safe for concurrent use, for watching what the background goroutine wrote.
+type lockedWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *lockedWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+// Wait until w contains want, up to a second.
+func written(w *lockedWriter, want string) string {
+	for range 1000 {
+		if w.String() == want {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return w.String()
+}
+
+func TestAutoFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := ringbuffer.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var out lockedWriter
+	f := ringbuffer.NewBytes(16).AutoFlush(&out, time.Second, 8)
+	f.SetClock(clock)
+
+	// below the watermark, written on schedule
+	f.Write([]byte("abc"))
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal("", out.String())
+	assert.Equal(3, f.Buffered())
+	clock.Advance(time.Second)
+	assert.Equal("abc", written(&out, "abc"))
+
+	// the schedule keeps going
+	f.Write([]byte("d"))
+	clock.Advance(time.Second)
+	assert.Equal("abcd", written(&out, "abcd"))
+
+	// reaching the watermark writes without waiting for the schedule
+	f.Write([]byte("01234567"))
+	assert.Equal("abcd01234567", written(&out, "abcd01234567"))
+
+	// flush on demand
+	f.Write([]byte("x"))
+	assert.Nil(f.Flush())
+	assert.Equal("abcd01234567x", out.String())
+	assert.Equal(0, f.Buffered())
+
+	// close writes out the rest
+	f.Write([]byte("yz"))
+	assert.Nil(f.Close())
+	assert.Equal("abcd01234567xyz", out.String())
+	n, err := f.Write([]byte("!"))
+	assert.Equal(0, n)
+	assert.Equal(ringbuffer.ErrClosed, err)
+	assert.Nil(f.Close())
+	assert.Nil(f.Flush())
+}
+
+func TestAutoFlushFull(t *testing.T) {
+	assert := assert.New(t)
+
+	// writes longer than the buffer wait for the background goroutine
+	var out lockedWriter
+	f := ringbuffer.NewBytes(4).AutoFlush(&out, 0, 100)
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n, err := f.Write([]byte("hello world\n"))
+			assert.Equal(12, n)
+			assert.Nil(err)
+		}()
+	}
+	wg.Wait()
+	assert.Nil(f.Close())
+	assert.Equal(48, len(out.String()))
+
+	// zero capacity can't buffer anything
+	f = ringbuffer.NewBytes(0).AutoFlush(&out, 0, 0)
+	_, err := f.Write([]byte("a"))
+	assert.Equal(ringbuffer.ErrFull, err)
+	assert.Nil(f.Close())
+}
+
+func TestAutoFlushError(t *testing.T) {
+	assert := assert.New(t)
+
+	f := ringbuffer.NewBytes(8).AutoFlush(&failingWriter{left: 2}, 0, 2)
+	f.Write([]byte("ab"))
+	assert.Nil(f.Flush())
+	f.Write([]byte("cd"))
+	assert.EqualError(f.Flush(), "disk full")
+	_, err := f.Write([]byte("e"))
+	assert.EqualError(err, "disk full")
+	assert.EqualError(f.Close(), "disk full")
+}
+
+func ExampleBytes_AutoFlush() {
+	var out bytes.Buffer
+	f := ringbuffer.NewBytes(64).AutoFlush(&out, 100*time.Millisecond, 32)
+	fmt.Fprintln(f, "starting")
+	fmt.Fprintln(f, "done")
+	f.Close()
+	fmt.Print(out.String())
+	// Output:
+	// starting
+	// done
+}
@@ -0,0 +1,88 @@
+package ringbuffer
+
+// Bounds and observation window for capacity auto-tuning.
+type AutoTuning struct {
+	Min    int // lower capacity bound
+	Max    int // upper capacity bound
+	Window int // number of Push/Pop calls per observation window, 1024 if zero
+}
+
+// Fixed length FIFO ring buffer which adjusts its capacity to the observed load.
+//
+// Push and Pop calls are grouped into observation windows. If any push failed during a window, capacity is doubled.
+// If no push failed and occupancy never exceeded a quarter of capacity, capacity is halved. Capacity always stays
+// within the configured bounds. Every adjustment migrates elements, see RingBuffer.Resize.
+type AutoTuned[T any] struct {
+	buf    RingBuffer[T]
+	tuning AutoTuning
+	ops    int
+	drops  int
+	peak   int
+	total  uint64
+}
+
+// Create a new auto-tuned buffer with the given initial capacity.
+func NewAutoTuned[T any](capacity int, tuning AutoTuning) AutoTuned[T] {
+	if tuning.Window <= 0 {
+		tuning.Window = 1024
+	}
+	return AutoTuned[T]{
+		buf:    New[T](capacity),
+		tuning: tuning,
+	}
+}
+
+// How many elements a buffer can store at the moment?
+func (b AutoTuned[T]) Cap() int {
+	return b.buf.Cap()
+}
+
+// How many elements are currently stored in the buffer?
+func (b AutoTuned[T]) Len() int {
+	return b.buf.Len()
+}
+
+// How many pushes failed due to lack of free space since the buffer was created?
+func (b AutoTuned[T]) Drops() uint64 {
+	return b.total
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *AutoTuned[T]) Push(v T) bool {
+	ok := b.buf.Push(v)
+	if !ok {
+		b.drops++
+		b.total++
+	}
+	b.observe()
+	return ok
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *AutoTuned[T]) Pop() (T, bool) {
+	v, ok := b.buf.Pop()
+	b.observe()
+	return v, ok
+}
+
+func (b *AutoTuned[T]) observe() {
+	b.peak = max(b.peak, b.buf.Len())
+	b.ops++
+	if b.ops < b.tuning.Window {
+		return
+	}
+
+	c := b.buf.Cap()
+	if b.drops > 0 && c < b.tuning.Max {
+		b.buf.Resize(min(max(c*2, 1), b.tuning.Max))
+	} else if b.drops == 0 && b.peak <= c/4 && c > b.tuning.Min {
+		b.buf.Resize(max(c/2, b.tuning.Min, b.peak))
+	}
+	b.ops = 0
+	b.drops = 0
+	b.peak = b.buf.Len()
+}
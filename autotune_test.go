@@ -0,0 +1,45 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAutoTuned(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewAutoTuned[int](2, ringbuffer.AutoTuning{Min: 2, Max: 8, Window: 4})
+	assert.Equal(2, b.Cap())
+
+	// saturation grows capacity up to Max
+	for i := 0; i < 20; i++ {
+		b.Push(i)
+	}
+	assert.Equal(8, b.Cap())
+	assert.Equal(8, b.Len())
+	assert.Equal(uint64(12), b.Drops())
+
+	// FIFO order survives resizing
+	for _, expected := range []int{0, 1, 4, 5, 8, 9, 10, 11} {
+		v, ok := b.Pop()
+		assert.Equal(true, ok)
+		assert.Equal(expected, v)
+	}
+
+	// idle windows shrink capacity down to Min
+	for i := 0; i < 16; i++ {
+		b.Pop()
+	}
+	assert.Equal(2, b.Cap())
+	assert.Equal(0, b.Len())
+}
+
+func ExampleAutoTuned() {
+	b := ringbuffer.NewAutoTuned[int](1, ringbuffer.AutoTuning{Min: 1, Max: 4, Window: 2})
+	b.Push(1)
+	b.Push(2) // fails and ends the window, capacity grows
+	fmt.Println(b.Cap(), b.Push(2))
+	// Output: 2 true
+}
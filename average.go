@@ -0,0 +1,72 @@
+package ringbuffer
+
+// Moving averages of float samples: simple moving average over the last N samples and exponentially weighted moving
+// average.
+//
+// Both are updated incrementally on every push. The simple average keeps a running sum, which is recomputed from the
+// stored samples once per window length of evictions to stop floating point error from accumulating. The exponential
+// average starts at the first sample and weighs every next one with alpha, which is fixed so that it can be
+// maintained in constant time.
+type MovingAverage struct {
+	buf     RingBuffer[float64]
+	sum     float64
+	evicted int
+	alpha   float64
+	ewma    float64
+	started bool
+}
+
+// Create a new moving average over the last n samples, with an exponential average smoothing factor 0 < alpha <= 1.
+func NewMovingAverage(n int, alpha float64) MovingAverage {
+	return MovingAverage{buf: New[float64](n), alpha: alpha}
+}
+
+// How many samples the simple moving average covers?
+func (m MovingAverage) Cap() int {
+	return m.buf.Cap()
+}
+
+// How many samples are currently in the window?
+func (m MovingAverage) Len() int {
+	return m.buf.Len()
+}
+
+// Add a sample, evicting the oldest one from the window if it is full.
+func (m *MovingAverage) Push(v float64) {
+	if m.started {
+		m.ewma += m.alpha * (v - m.ewma)
+	} else {
+		m.ewma = v
+		m.started = true
+	}
+	if m.buf.Cap() == 0 {
+		return
+	}
+	if m.buf.IsFull() {
+		old, _ := m.buf.Pop()
+		m.sum -= old
+		m.evicted++
+	}
+	m.buf.Push(v)
+	m.sum += v
+	if m.evicted >= m.buf.Cap() {
+		m.sum = 0
+		for s := range m.buf.Values() {
+			m.sum += s
+		}
+		m.evicted = 0
+	}
+}
+
+// Get the average of the samples in the window. Returns 0 if the window is empty.
+func (m MovingAverage) SMA() float64 {
+	if m.buf.Len() == 0 {
+		return 0
+	}
+	return m.sum / float64(m.buf.Len())
+}
+
+// Get the exponentially weighted average of all samples pushed so far. Returns 0 if nothing was pushed.
+func (m MovingAverage) EWMA() float64 {
+	return m.ewma
+}
@@ -0,0 +1,54 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMovingAverage(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var m ringbuffer.MovingAverage
+		assert.Equal(0.0, m.SMA())
+		assert.Equal(0.0, m.EWMA())
+		m.Push(1)
+		assert.Equal(0, m.Len())
+		assert.Equal(0.0, m.SMA())
+	}
+
+	m := ringbuffer.NewMovingAverage(3, 0.5)
+	assert.Equal(3, m.Cap())
+	m.Push(4)
+	assert.Equal(4.0, m.SMA())
+	assert.Equal(4.0, m.EWMA())
+	m.Push(8)
+	assert.Equal(6.0, m.SMA())
+	assert.Equal(6.0, m.EWMA())
+	m.Push(0)
+	assert.Equal(4.0, m.SMA())
+	assert.Equal(3.0, m.EWMA())
+
+	// 4 leaves the window
+	m.Push(1)
+	assert.Equal(3, m.Len())
+	assert.Equal(3.0, m.SMA())
+	assert.Equal(2.0, m.EWMA())
+
+	// no drift over many evictions
+	for i := 0; i < 100000; i++ {
+		m.Push(0.1 * float64(i%7))
+	}
+	assert.InDelta((0.1*float64(99997%7)+0.1*float64(99998%7)+0.1*float64(99999%7))/3, m.SMA(), 1e-12)
+}
+
+func ExampleMovingAverage() {
+	m := ringbuffer.NewMovingAverage(4, 0.25)
+	for _, v := range []float64{10, 20, 30, 40, 50} {
+		m.Push(v)
+	}
+	fmt.Printf("%.2f %.2f\n", m.SMA(), m.EWMA())
+	// Output: 35.00 29.49
+}
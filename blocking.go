@@ -0,0 +1,192 @@
+package ringbuffer
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Returned by waiting operations on a buffer which was closed.
+var ErrClosed = errors.New("ringbuffer: buffer closed")
+
+// Fixed length FIFO ring buffer safe for concurrent use, where Push waits for free space and Pop waits for elements.
+//
+// Closing the buffer wakes up all waiting goroutines: pushes fail from then on, pops return the remaining elements
+// and fail once the buffer is empty. Must not be copied after first use.
+type Blocking[T any] struct {
+	mu       sync.Mutex
+	notEmpty sync.Cond
+	notFull  sync.Cond
+	changed  sync.Cond // broadcast on every state change, for waiters which don't consume
+	buf      RingBuffer[T]
+	closed   bool
+	notify   notifier
+	mirrors  []*Mirror[T]
+}
+
+// Create a new buffer which can store capacity elements.
+func NewBlocking[T any](capacity int) *Blocking[T] {
+	b := &Blocking[T]{buf: New[T](capacity)}
+	b.notEmpty.L = &b.mu
+	b.notFull.L = &b.mu
+	b.changed.L = &b.mu
+	return b
+}
+
+// How many elements a buffer can store?
+func (b *Blocking[T]) Cap() int {
+	return b.buf.Cap()
+}
+
+// How many elements are currently stored in the buffer?
+func (b *Blocking[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Len()
+}
+
+// Push a new element to the buffer, waiting for free space if the buffer is full.
+//
+// Returns true on success. Returns false if the buffer was closed or has zero capacity.
+func (b *Blocking[T]) Push(v T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.buf.Cap() == 0 {
+		return false
+	}
+	for !b.closed && b.buf.IsFull() {
+		b.notFull.Wait()
+	}
+	if b.closed {
+		return false
+	}
+	b.buf.Push(v)
+	tap(b.mirrors, v)
+	b.pushed()
+	return true
+}
+
+// Try to push a new element to the buffer without waiting.
+//
+// Returns true on success. Returns false if there is no free space or the buffer was closed.
+func (b *Blocking[T]) TryPush(v T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed || !b.buf.Push(v) {
+		return false
+	}
+	tap(b.mirrors, v)
+	b.pushed()
+	return true
+}
+
+// Pop an element from the buffer, waiting if the buffer is empty.
+//
+// Returns the popped element and true on success. Returns default value and false if the buffer was closed and there
+// are no elements left.
+func (b *Blocking[T]) Pop() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for !b.closed && b.buf.IsEmpty() {
+		b.notEmpty.Wait()
+	}
+	v, ok := b.buf.Pop()
+	if ok {
+		b.popped()
+	}
+	return v, ok
+}
+
+// Try to pop an element from the buffer without waiting.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *Blocking[T]) TryPop() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, ok := b.buf.Pop()
+	if ok {
+		b.popped()
+	}
+	return v, ok
+}
+
+// Look at the oldest element without removing it from the buffer, waiting until there is one.
+//
+// Returns the oldest element and nil on success. Returns default value and ctx.Err() if ctx is done first, or
+// ErrClosed if the buffer was closed and there are no elements left. Use context.WithTimeout for a timeout.
+func (b *Blocking[T]) PeekWait(ctx context.Context) (T, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.wakeOn(ctx)()
+	for !b.closed && b.buf.IsEmpty() && ctx.Err() == nil {
+		b.changed.Wait()
+	}
+	if v, ok := b.buf.Peek(); ok {
+		return v, nil
+	}
+	var def T
+	if b.closed {
+		return def, ErrClosed
+	}
+	return def, ctx.Err()
+}
+
+// Wait until the buffer state satisfies pred, e.g. "at least 100 elements" or "contains an element with flag X".
+//
+// Pred is called with the lock held, initially and after every change of the buffer, with a view of the buffer which
+// it must neither modify nor keep. Returns nil once pred returns true. Returns ctx.Err() if ctx is done first, or
+// ErrClosed if the buffer was closed and pred still doesn't hold.
+func (b *Blocking[T]) Watch(ctx context.Context, pred func(b RingBuffer[T]) bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.wakeOn(ctx)()
+	for !pred(b.buf) {
+		if b.closed {
+			return ErrClosed
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		b.changed.Wait()
+	}
+	return nil
+}
+
+// Close the buffer, waking up all waiting goroutines. Closing a closed buffer does nothing.
+func (b *Blocking[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.notEmpty.Broadcast()
+	b.notFull.Broadcast()
+	b.changed.Broadcast()
+	if b.notify.readable != nil {
+		signal(b.notify.readable)
+	}
+	if b.notify.writable != nil {
+		signal(b.notify.writable)
+	}
+}
+
+// Wake up a waiting pop and fire signals after a push added one element. The lock must be held.
+func (b *Blocking[T]) pushed() {
+	b.notify.update(b.buf.Len() == 1, false, false, b.buf.IsFull())
+	b.notEmpty.Signal()
+	b.changed.Broadcast()
+}
+
+// Wake up a waiting push and fire signals after a pop removed one element. The lock must be held.
+func (b *Blocking[T]) popped() {
+	b.notify.update(false, b.buf.Free() == 1, b.buf.IsEmpty(), false)
+	b.notFull.Signal()
+	b.changed.Broadcast()
+}
+
+// Wake up goroutines waiting for a state change once ctx is done. Call the returned function when done waiting.
+func (b *Blocking[T]) wakeOn(ctx context.Context) (stop func() bool) {
+	return context.AfterFunc(ctx, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.changed.Broadcast()
+	})
+}
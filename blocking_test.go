@@ -0,0 +1,200 @@
+package ringbuffer_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlocking(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		b := ringbuffer.NewBlocking[int](0)
+		assert.Equal(false, b.Push(1))
+		assert.Equal(false, b.TryPush(1))
+		_, ok := b.TryPop()
+		assert.Equal(false, ok)
+	}
+
+	b := ringbuffer.NewBlocking[int](2)
+	assert.Equal(true, b.TryPush(1))
+	assert.Equal(true, b.Push(2))
+	assert.Equal(false, b.TryPush(3))
+
+	// push waits until there is space
+	pushed := make(chan bool)
+	go func() { pushed <- b.Push(3) }()
+	v, ok := b.Pop()
+	assert.Equal(true, ok)
+	assert.Equal(1, v)
+	assert.Equal(true, <-pushed)
+
+	v, _ = b.TryPop()
+	assert.Equal(2, v)
+	v, _ = b.Pop()
+	assert.Equal(3, v)
+
+	// pop waits until there is an element
+	popped := make(chan int)
+	go func() {
+		v, _ := b.Pop()
+		popped <- v
+	}()
+	b.Push(4)
+	assert.Equal(4, <-popped)
+	assert.Equal(0, b.Len())
+
+	// close wakes up waiting goroutines and keeps remaining elements
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, ok := b.Pop()
+		assert.Equal(false, ok)
+	}()
+	b.Close()
+	wg.Wait()
+	assert.Equal(false, b.Push(5))
+
+	b = ringbuffer.NewBlocking[int](1)
+	b.Push(1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.Equal(false, b.Push(2))
+	}()
+	b.Close()
+	wg.Wait()
+	v, ok = b.Pop()
+	assert.Equal(true, ok)
+	assert.Equal(1, v)
+	_, ok = b.Pop()
+	assert.Equal(false, ok)
+}
+
+func TestBlockingPeekWait(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBlocking[int](2)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := b.PeekWait(ctx)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	// a peeker doesn't take the wakeup away from a pop waiting at the same time
+	peeked := make(chan int)
+	popped := make(chan int)
+	go func() {
+		v, _ := b.PeekWait(context.Background())
+		peeked <- v
+	}()
+	go func() {
+		v, _ := b.Pop()
+		popped <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	b.Push(1)
+	b.Push(2)
+	assert.Equal(1, <-popped)
+	assert.Contains([]int{1, 2}, <-peeked)
+
+	v, err := b.PeekWait(context.Background())
+	assert.NoError(err)
+	assert.Equal(2, v)
+	assert.Equal(1, b.Len())
+	b.Close()
+	v, err = b.PeekWait(context.Background())
+	assert.NoError(err)
+	assert.Equal(2, v)
+	b.Pop()
+	_, err = b.PeekWait(context.Background())
+	assert.ErrorIs(err, ringbuffer.ErrClosed)
+}
+
+func TestBlockingWatch(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBlocking[int](8)
+	atLeast := func(n int) func(ringbuffer.RingBuffer[int]) bool {
+		return func(b ringbuffer.RingBuffer[int]) bool { return b.Len() >= n }
+	}
+	assert.NoError(b.Watch(context.Background(), atLeast(0)))
+
+	// blocks until enough elements arrive
+	go func() {
+		for i := 1; i <= 5; i++ {
+			b.Push(i)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	assert.NoError(b.Watch(context.Background(), atLeast(3)))
+	assert.GreaterOrEqual(b.Len(), 3)
+	assert.NoError(b.Watch(context.Background(), func(b ringbuffer.RingBuffer[int]) bool {
+		v, ok := b.Back()
+		return ok && v == 5
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(b.Watch(ctx, atLeast(6)), context.DeadlineExceeded)
+	b.Close()
+	assert.ErrorIs(b.Watch(context.Background(), atLeast(6)), ringbuffer.ErrClosed)
+	assert.NoError(b.Watch(context.Background(), atLeast(5)))
+}
+
+func TestBlockingConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	const producers = 4
+	const perProducer = 1000
+	b := ringbuffer.NewBlocking[int](8)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				b.Push(1)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		b.Close()
+	}()
+
+	sum := 0
+	for {
+		v, ok := b.Pop()
+		if !ok {
+			break
+		}
+		sum += v
+	}
+	assert.Equal(producers*perProducer, sum)
+}
+
+func ExampleBlocking() {
+	jobs := ringbuffer.NewBlocking[int](2)
+	go func() {
+		for i := 1; i <= 5; i++ {
+			jobs.Push(i)
+		}
+		jobs.Close()
+	}()
+	for {
+		v, ok := jobs.Pop()
+		if !ok {
+			break
+		}
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+	// Output: 1 2 3 4 5
+}
@@ -0,0 +1,271 @@
+package ringbuffer
+
+import (
+	"sync"
+	"time"
+)
+
+// What Publish does when the slowest subscriber has not read the oldest element yet.
+type BroadcastMode int
+
+const (
+	// Publish waits until the slowest subscriber reads the oldest element. This is the default.
+	BlockSlowest BroadcastMode = iota
+	// Publish overwrites the oldest element. Subscribers which were lapped skip the lost elements, see Missed.
+	LapSlowest
+)
+
+// Fixed length ring safe for concurrent use, which delivers every published element to every subscriber.
+//
+// Each subscriber has its own read position over the shared storage, elements are not copied per subscriber. A
+// subscriber only sees elements published after it subscribed. Closing wakes up all waiting goroutines: publishing
+// fails from then on, subscribers read the remaining elements. Must not be copied after first use.
+type Broadcast[T any] struct {
+	mu     sync.Mutex
+	cond   sync.Cond
+	buffer []T
+	write  uint64 // elements published so far
+	subs   []*Subscriber[T]
+	groups map[string]*Group[T]
+	mode   BroadcastMode
+	closed bool
+	clock  Clock
+}
+
+// Read position of one subscriber of a Broadcast. Must only be used from one goroutine at a time.
+type Subscriber[T any] struct {
+	b        *Broadcast[T]
+	name     string
+	read     uint64
+	missed   uint64
+	done     bool
+	lastRead time.Time // or when it subscribed, before the first read
+}
+
+// State of one subscriber, as reported by Broadcast.Subscribers.
+type SubscriberStats struct {
+	Name     string    `json:"name"`
+	Lag      uint64    `json:"lag"`
+	Missed   uint64    `json:"missed"`
+	LastRead time.Time `json:"last_read"`
+}
+
+// Create a new ring which stores the last capacity elements.
+func NewBroadcast[T any](capacity int, mode BroadcastMode) *Broadcast[T] {
+	b := &Broadcast[T]{buffer: make([]T, max(capacity, 0)), mode: mode}
+	b.cond.L = &b.mu
+	return b
+}
+
+// Use the given clock instead of the system clock for read timestamps. Nil restores the system clock.
+func (b *Broadcast[T]) SetClock(c Clock) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.clock = c
+}
+
+// How many elements a ring can store?
+func (b *Broadcast[T]) Cap() int {
+	return len(b.buffer)
+}
+
+// How many stored elements are waiting to be read by the slowest subscriber?
+func (b *Broadcast[T]) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := uint64(0)
+	for _, s := range b.subs {
+		s.catchUp()
+		n = max(n, b.write-s.read)
+	}
+	return int(n)
+}
+
+// Get the state of every subscriber in subscription order, to find the slow one holding back publishing or about
+// to be lapped. DebugHandler reports it for a Broadcast.
+func (b *Broadcast[T]) Subscribers() []SubscriberStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]SubscriberStats, 0, len(b.subs))
+	for _, s := range b.subs {
+		s.catchUp()
+		out = append(out, SubscriberStats{Name: s.name, Lag: b.write - s.read, Missed: s.missed, LastRead: s.lastRead})
+	}
+	return out
+}
+
+// Publish a new element to all subscribers. With BlockSlowest waits until the slowest subscriber makes space.
+//
+// Returns true on success. Returns false if the ring was closed or has zero capacity.
+func (b *Broadcast[T]) Publish(v T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.buffer) == 0 {
+		return false
+	}
+	for !b.closed && b.full() {
+		b.cond.Wait()
+	}
+	if b.closed {
+		return false
+	}
+	b.publish(v)
+	return true
+}
+
+// Try to publish a new element to all subscribers without waiting.
+//
+// Returns true on success. Returns false if the slowest subscriber has no free space with BlockSlowest, or if the
+// ring was closed or has zero capacity.
+func (b *Broadcast[T]) TryPublish(v T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed || len(b.buffer) == 0 || b.full() {
+		return false
+	}
+	b.publish(v)
+	return true
+}
+
+// Is there no free space for the slowest subscriber or consumer group? Never true with LapSlowest. The lock must be
+// held.
+func (b *Broadcast[T]) full() bool {
+	if b.mode == LapSlowest {
+		return false
+	}
+	for _, s := range b.subs {
+		if b.write-s.read >= uint64(len(b.buffer)) {
+			return true
+		}
+	}
+	for _, g := range b.groups {
+		if b.write-g.committed >= uint64(len(b.buffer)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Store an element and wake up waiting subscribers. The lock must be held.
+func (b *Broadcast[T]) publish(v T) {
+	b.buffer[b.write%uint64(len(b.buffer))] = v
+	b.write++
+	b.cond.Broadcast()
+}
+
+// Add a subscriber which receives elements published from now on.
+func (b *Broadcast[T]) Subscribe() *Subscriber[T] {
+	return b.SubscribeNamed("")
+}
+
+// Add a subscriber which receives elements published from now on, named for Subscribers.
+func (b *Broadcast[T]) SubscribeNamed(name string) *Subscriber[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribe(name, b.write)
+}
+
+// The lock must be held.
+func (b *Broadcast[T]) subscribe(name string, read uint64) *Subscriber[T] {
+	s := &Subscriber[T]{b: b, name: name, read: read, lastRead: clockNow(b.clock)}
+	b.subs = append(b.subs, s)
+	return s
+}
+
+// Close the ring, waking up all waiting goroutines. Closing a closed ring does nothing.
+func (b *Broadcast[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.cond.Broadcast()
+}
+
+// How many elements are waiting to be read by this subscriber?
+func (s *Subscriber[T]) Len() int {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	s.catchUp()
+	return int(s.b.write - s.read)
+}
+
+// What is the name the subscriber was created with?
+func (s *Subscriber[T]) Name() string {
+	return s.name
+}
+
+// When did the subscriber read an element last? Before the first read, it is when it subscribed.
+func (s *Subscriber[T]) LastRead() time.Time {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	return s.lastRead
+}
+
+// How many elements this subscriber lost by being lapped so far?
+func (s *Subscriber[T]) Missed() uint64 {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	s.catchUp()
+	return s.missed
+}
+
+// Read the next element, waiting if there is none.
+//
+// Returns the element and true on success. Returns default value and false if the subscriber was removed, or if the
+// ring was closed and there are no elements left.
+func (s *Subscriber[T]) Next() (T, bool) {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	for !s.done && !s.b.closed && s.read == s.b.write {
+		s.b.cond.Wait()
+	}
+	return s.next()
+}
+
+// Try to read the next element without waiting.
+//
+// Returns the element and true on success. Returns default value and false if there were no elements to read.
+func (s *Subscriber[T]) TryNext() (T, bool) {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	return s.next()
+}
+
+// Stop receiving elements, so that the subscriber no longer holds back publishing. Its pending reads fail.
+func (s *Subscriber[T]) Unsubscribe() {
+	b := s.b
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if s.done {
+		return
+	}
+	s.done = true
+	for i, o := range b.subs {
+		if o == s {
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			break
+		}
+	}
+	b.cond.Broadcast()
+}
+
+// The lock must be held.
+func (s *Subscriber[T]) next() (T, bool) {
+	s.catchUp()
+	if s.done || s.read == s.b.write {
+		var def T
+		return def, false
+	}
+	v := s.b.buffer[s.read%uint64(len(s.b.buffer))]
+	s.read++
+	s.lastRead = clockNow(s.b.clock)
+	s.b.cond.Broadcast()
+	return v, true
+}
+
+// Skip elements overwritten since the last read. The lock must be held.
+func (s *Subscriber[T]) catchUp() {
+	if oldest := s.b.write - min(s.b.write, uint64(len(s.b.buffer))); s.read < oldest {
+		s.missed += oldest - s.read
+		s.read = oldest
+	}
+}
@@ -0,0 +1,176 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBroadcast(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		b := ringbuffer.NewBroadcast[int](0, ringbuffer.BlockSlowest)
+		assert.Equal(false, b.Publish(1))
+		assert.Equal(false, b.TryPublish(1))
+	}
+
+	b := ringbuffer.NewBroadcast[int](2, ringbuffer.BlockSlowest)
+	assert.Equal(true, b.TryPublish(0)) // nobody subscribed yet
+	fast := b.Subscribe()
+	slow := b.Subscribe()
+	assert.Equal(0, fast.Len())
+	assert.Equal(true, b.Publish(1))
+	assert.Equal(true, b.Publish(2))
+	assert.Equal(false, b.TryPublish(3))
+
+	// every subscriber sees every element
+	for _, s := range []*ringbuffer.Subscriber[int]{fast, slow} {
+		assert.Equal(2, s.Len())
+		v, ok := s.TryNext()
+		assert.Equal(true, ok)
+		assert.Equal(1, v)
+	}
+	v, _ := fast.Next()
+	assert.Equal(2, v)
+	_, ok := fast.TryNext()
+	assert.Equal(false, ok)
+
+	// the slow subscriber holds back publishing
+	published := make(chan bool)
+	go func() { published <- b.Publish(3) }()
+	v, _ = slow.Next()
+	assert.Equal(2, v)
+	assert.Equal(true, <-published)
+	assert.Equal(true, b.TryPublish(4))
+	assert.Equal(false, b.TryPublish(5))
+	slow.Unsubscribe()
+	slow.Unsubscribe()
+	_, ok = slow.TryNext()
+	assert.Equal(false, ok)
+	assert.Equal(2, fast.Len())
+	fast.Next()
+	assert.Equal(true, b.TryPublish(5))
+
+	// close wakes up waiting subscribers and keeps remaining elements
+	var wg sync.WaitGroup
+	late := b.Subscribe()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, ok := late.Next()
+		assert.Equal(false, ok)
+	}()
+	b.Close()
+	wg.Wait()
+	assert.Equal(false, b.Publish(6))
+	v, _ = fast.Next()
+	assert.Equal(4, v)
+	v, _ = fast.Next()
+	assert.Equal(5, v)
+	_, ok = fast.Next()
+	assert.Equal(false, ok)
+	assert.Equal(uint64(0), fast.Missed())
+}
+
+func TestBroadcastLap(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBroadcast[int](3, ringbuffer.LapSlowest)
+	s := b.Subscribe()
+	for i := 1; i <= 3; i++ {
+		assert.Equal(true, b.TryPublish(i))
+	}
+	v, _ := s.Next()
+	assert.Equal(1, v)
+	for i := 4; i <= 7; i++ {
+		assert.Equal(true, b.Publish(i))
+	}
+	// 2, 3 and 4 were overwritten
+	assert.Equal(3, s.Len())
+	assert.Equal(uint64(3), s.Missed())
+	v, _ = s.Next()
+	assert.Equal(5, v)
+}
+
+func TestBroadcastSubscribers(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := ringbuffer.NewManualClock(start)
+	b := ringbuffer.NewBroadcast[int](3, ringbuffer.LapSlowest)
+	b.SetClock(clock)
+	fast := b.SubscribeNamed("fast")
+	slow := b.SubscribeNamed("slow")
+	anon := b.Subscribe()
+	assert.Equal("fast", fast.Name())
+	assert.Equal("", anon.Name())
+	assert.Equal(0, b.Len())
+	assert.Equal(start, slow.LastRead())
+
+	for i := 0; i < 5; i++ {
+		b.Publish(i)
+		clock.Advance(time.Second)
+		fast.Next()
+	}
+	slow.Next()
+	assert.Equal(3, b.Len())
+	assert.Equal([]ringbuffer.SubscriberStats{
+		{Name: "fast", Lag: 0, Missed: 0, LastRead: start.Add(5 * time.Second)},
+		{Name: "slow", Lag: 2, Missed: 2, LastRead: start.Add(5 * time.Second)},
+		{Name: "", Lag: 3, Missed: 2, LastRead: start},
+	}, b.Subscribers())
+
+	anon.Unsubscribe()
+	assert.Equal(2, len(b.Subscribers()))
+}
+
+func TestBroadcastConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	const n = 10000
+	b := ringbuffer.NewBroadcast[int](8, ringbuffer.BlockSlowest)
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		s := b.Subscribe()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for want := 0; ; want++ {
+				v, ok := s.Next()
+				if !ok {
+					assert.Equal(n, want)
+					return
+				}
+				if v != want {
+					assert.Equal(want, v)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		b.Publish(i)
+	}
+	b.Close()
+	wg.Wait()
+}
+
+func ExampleBroadcast() {
+	b := ringbuffer.NewBroadcast[string](4, ringbuffer.BlockSlowest)
+	log := b.Subscribe()
+	audit := b.Subscribe()
+	b.Publish("login")
+	b.Publish("logout")
+	b.Close()
+	for _, s := range []*ringbuffer.Subscriber[string]{log, audit} {
+		for v, ok := s.Next(); ok; v, ok = s.Next() {
+			fmt.Print(v, " ")
+		}
+	}
+	fmt.Println()
+	// Output: login logout login logout
+}
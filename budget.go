@@ -0,0 +1,81 @@
+package ringbuffer
+
+import (
+	"unsafe"
+)
+
+// Smallest number of slots a Budgeted buffer keeps once it has grown.
+const budgetedMinSlots = 8
+
+// FIFO ring buffer bounded by the total size of stored elements rather than by their count.
+//
+// Element sizes are reported by a user-supplied function, which must return the same value for the same element
+// every time, negative sizes count as 0. Every element is charged its reported size plus the size of its slot,
+// unsafe.Sizeof(T), so empty elements still count against the budget. Storage for element slots doubles on demand and
+// halves once occupancy drops to a quarter, migrating elements the same way RingBuffer.Resize does, so it stays
+// proportional to the number of stored elements.
+type Budgeted[T any] struct {
+	buf    RingBuffer[T]
+	size   func(T) int
+	budget int
+	used   int
+}
+
+// Create a new buffer which can store elements totalling up to budget bytes, as measured by size.
+func NewBudgeted[T any](budget int, size func(T) int) Budgeted[T] {
+	return Budgeted[T]{
+		size:   size,
+		budget: budget,
+	}
+}
+
+// How many bytes a buffer can store?
+func (b Budgeted[T]) Budget() int {
+	return b.budget
+}
+
+// How many bytes are taken by currently stored elements, including their slots?
+func (b Budgeted[T]) Bytes() int {
+	return b.used
+}
+
+// How many elements are currently stored in the buffer?
+func (b Budgeted[T]) Len() int {
+	return b.buf.Len()
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if the element doesn't fit into the remaining budget and push failed.
+func (b *Budgeted[T]) Push(v T) bool {
+	sz := b.charge(v)
+	if b.used+sz > b.budget {
+		return false
+	}
+	if b.buf.Len() == b.buf.Cap() {
+		b.buf.Resize(max(b.buf.Cap()*2, budgetedMinSlots))
+	}
+	b.buf.Push(v)
+	b.used += sz
+	return true
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *Budgeted[T]) Pop() (T, bool) {
+	v, ok := b.buf.Pop()
+	if !ok {
+		return v, false
+	}
+	b.used -= b.charge(v)
+	if c := b.buf.Cap(); c > budgetedMinSlots && b.buf.Len() <= c/4 {
+		b.buf.Resize(c / 2)
+	}
+	return v, true
+}
+
+// How many bytes of the budget an element takes?
+func (b *Budgeted[T]) charge(v T) int {
+	return max(b.size(v), 0) + int(unsafe.Sizeof(v))
+}
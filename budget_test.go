@@ -0,0 +1,73 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"unsafe"
+)
+
+func TestBudgeted(t *testing.T) {
+	assert := assert.New(t)
+
+	size := func(s string) int { return len(s) }
+	slot := int(unsafe.Sizeof(""))
+	b := ringbuffer.NewBudgeted(10+3*slot, size)
+	assert.Equal(10+3*slot, b.Budget())
+	assert.Equal(true, b.Push("hello"))
+	assert.Equal(true, b.Push("abc"))
+	assert.Equal(false, b.Push("xyz"))
+	assert.Equal(true, b.Push("x"))
+	assert.Equal(false, b.Push(""))
+	assert.Equal(9+3*slot, b.Bytes())
+	assert.Equal(3, b.Len())
+
+	v, ok := b.Pop()
+	assert.Equal("hello", v)
+	assert.Equal(true, ok)
+	assert.Equal(4+2*slot, b.Bytes())
+	assert.Equal(true, b.Push("y"))
+	var got string
+	for {
+		v, ok := b.Pop()
+		if !ok {
+			break
+		}
+		got += v
+	}
+	assert.Equal("abcxy", got)
+	assert.Equal(0, b.Bytes())
+
+	// empty elements are charged their slot, so they can't grow storage without bound
+	e := ringbuffer.NewBudgeted(20*slot, size)
+	for i := 0; i < 20; i++ {
+		assert.Equal(true, e.Push(""))
+	}
+	assert.Equal(false, e.Push(""))
+	assert.Equal(20, e.Len())
+
+	// many small elements grow slot storage, which shrinks back as they are popped
+	m := ringbuffer.NewBudgeted(1000*(1+slot), size)
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 1000; i++ {
+			assert.Equal(true, m.Push(string(rune('a'+i%26))))
+		}
+		assert.Equal(false, m.Push("a"))
+		for i := 0; i < 1000; i++ {
+			v, _ := m.Pop()
+			if v != string(rune('a'+i%26)) {
+				assert.Equal(string(rune('a'+i%26)), v)
+			}
+		}
+		assert.Equal(0, m.Len())
+		assert.Equal(0, m.Bytes())
+	}
+}
+
+func ExampleBudgeted() {
+	// every element is also charged its slice header, 24 bytes on 64-bit platforms
+	b := ringbuffer.NewBudgeted(80, func(p []byte) int { return len(p) })
+	fmt.Println(b.Push(make([]byte, 30)), b.Push(make([]byte, 30)), b.Push(make([]byte, 2)))
+	// Output: true false true
+}
@@ -0,0 +1,248 @@
+package ringbuffer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// Returned by writes to a byte ring buffer with no free space left for the whole input.
+var ErrFull = errors.New("ringbuffer: buffer is full")
+
+// Fixed length FIFO ring buffer of bytes, usable as an io.Reader and io.Writer.
+type Bytes struct {
+	buf       RingBuffer[byte]
+	canUnread bool   // last operation was a read, the byte before read position is still intact
+	scratch   []byte // stitched view of wrapped data returned by Peek
+}
+
+// Create a new byte buffer which can store capacity bytes.
+func NewBytes(capacity int) *Bytes {
+	return &Bytes{buf: New[byte](capacity)}
+}
+
+// How many bytes a buffer can store?
+func (b *Bytes) Cap() int {
+	return b.buf.Cap()
+}
+
+// How many bytes are currently stored in the buffer?
+func (b *Bytes) Len() int {
+	return b.buf.Len()
+}
+
+// How many more bytes can be written before the buffer is full?
+func (b *Bytes) Free() int {
+	return b.buf.Free()
+}
+
+// Discard all stored bytes.
+func (b *Bytes) Reset() {
+	b.buf.read, b.buf.write = 0, 0
+	b.canUnread = false
+}
+
+// Write as many bytes of p as fit into the buffer.
+//
+// Returns the number of bytes written. If not all of p fit, the error is ErrFull.
+func (b *Bytes) Write(p []byte) (int, error) {
+	b.canUnread = false
+	n := b.buf.PushMany(p)
+	if n < len(p) {
+		return n, ErrFull
+	}
+	return n, nil
+}
+
+// Read up to len(p) bytes from the buffer into p.
+//
+// Returns the number of bytes read. If the buffer is empty and p is not, the error is io.EOF.
+func (b *Bytes) Read(p []byte) (int, error) {
+	if len(p) > 0 && b.buf.IsEmpty() {
+		b.canUnread = false
+		return 0, io.EOF
+	}
+	n := b.buf.PopMany(p)
+	b.canUnread = n > 0
+	return n, nil
+}
+
+// Write a single byte to the buffer.
+//
+// Returns ErrFull if there is no free space.
+func (b *Bytes) WriteByte(c byte) error {
+	b.canUnread = false
+	if !b.buf.Push(c) {
+		return ErrFull
+	}
+	return nil
+}
+
+// Read a single byte from the buffer.
+//
+// Returns io.EOF if the buffer is empty.
+func (b *Bytes) ReadByte() (byte, error) {
+	c, ok := b.buf.Pop()
+	b.canUnread = ok
+	if !ok {
+		return 0, io.EOF
+	}
+	return c, nil
+}
+
+// Put the most recently read byte back into the buffer, so that it is read again.
+//
+// Only valid right after a read, returns bufio.ErrInvalidUnreadByte otherwise.
+func (b *Bytes) UnreadByte() error {
+	if !b.canUnread {
+		return bufio.ErrInvalidUnreadByte
+	}
+	b.canUnread = false
+	b.buf.read = (b.buf.read - 1 + len(b.buf.buffer)) % len(b.buf.buffer)
+	b.buf.seq--
+	return nil
+}
+
+// Get the next n bytes without removing them from the buffer.
+//
+// The returned slice points into the buffer if the bytes are contiguous, or into an internal copy if they wrap
+// around, either way it stays valid until the next operation on the buffer. If fewer than n bytes are stored, returns
+// all of them and io.EOF.
+func (b *Bytes) Peek(n int) ([]byte, error) {
+	if n < 0 {
+		return nil, bufio.ErrNegativeCount
+	}
+	b.canUnread = false
+	var err error
+	if n > b.buf.Len() {
+		n, err = b.buf.Len(), io.EOF
+	}
+	x, y := b.buf.Regions()
+	if n <= len(x) {
+		return x[:n], err
+	}
+	b.scratch = append(append(b.scratch[:0], x...), y[:n-len(x)]...)
+	return b.scratch, err
+}
+
+// Remove the next n bytes from the buffer without reading them.
+//
+// Returns the number of bytes discarded. If fewer than n bytes are stored, all of them are discarded and the error
+// is io.EOF.
+func (b *Bytes) Discard(n int) (int, error) {
+	if n < 0 {
+		return 0, bufio.ErrNegativeCount
+	}
+	b.canUnread = false
+	var err error
+	if n > b.buf.Len() {
+		n, err = b.buf.Len(), io.EOF
+	}
+	b.buf.consume(n)
+	return n, err
+}
+
+// Read until the first occurrence of delim, returning a new slice with the data up to and including the delimiter.
+//
+// If delim is not found, all stored bytes are read and returned with io.EOF, as bufio.Reader does.
+func (b *Bytes) ReadBytes(delim byte) ([]byte, error) {
+	x, y := b.buf.Regions()
+	n, err := len(x)+len(y), io.EOF
+	if i := bytes.IndexByte(x, delim); i >= 0 {
+		n, err = i+1, nil
+	} else if i := bytes.IndexByte(y, delim); i >= 0 {
+		n, err = len(x)+i+1, nil
+	}
+	out := make([]byte, n)
+	b.buf.PopMany(out)
+	b.canUnread = n > 0
+	return out, err
+}
+
+// Read until the first occurrence of delim, returning a string with the data up to and including the delimiter.
+//
+// If delim is not found, all stored bytes are read and returned with io.EOF, as bufio.Reader does.
+func (b *Bytes) ReadString(delim byte) (string, error) {
+	out, err := b.ReadBytes(delim)
+	return string(out), err
+}
+
+// Get the free space of the buffer as (up to) two contiguous regions, to be filled in place and then committed.
+//
+// The regions stay valid until the next operation on the buffer.
+func (b *Bytes) WriteSlices() ([]byte, []byte) {
+	return b.buf.freeRegions()
+}
+
+// Mark n bytes at the start of the regions returned by WriteSlices as written. Panics if n exceeds the free space.
+func (b *Bytes) Commit(n int) {
+	if n < 0 || n > b.buf.Free() {
+		panic("ringbuffer: commit out of range")
+	}
+	b.canUnread = false
+	b.buf.commit(n)
+}
+
+// Get stored bytes as (up to) two contiguous regions, to be processed in place and then consumed.
+//
+// The regions stay valid until the next operation on the buffer.
+func (b *Bytes) ReadSlices() ([]byte, []byte) {
+	return b.buf.Regions()
+}
+
+// Remove n oldest bytes from the buffer, typically after processing them through ReadSlices. Panics if n exceeds the
+// number of stored bytes.
+func (b *Bytes) Consume(n int) {
+	if n < 0 || n > b.buf.Len() {
+		panic("ringbuffer: consume out of range")
+	}
+	b.canUnread = false
+	b.buf.consume(n)
+}
+
+// Read from r directly into the free space of the buffer, until r returns io.EOF or the buffer is full.
+//
+// Returns the number of bytes read. Reaching io.EOF is not an error. If the buffer fills up before r is exhausted,
+// the error is ErrFull.
+func (b *Bytes) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	for {
+		x, _ := b.buf.freeRegions()
+		if len(x) == 0 {
+			return n, ErrFull
+		}
+		m, err := r.Read(x)
+		b.canUnread = false
+		b.buf.commit(m)
+		n += int64(m)
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+	}
+}
+
+// Write stored bytes to w directly from the buffer, until the buffer is empty or w fails.
+//
+// Returns the number of bytes written, which are removed from the buffer. Bytes not written stay in the buffer.
+func (b *Bytes) WriteTo(w io.Writer) (int64, error) {
+	var n int64
+	for {
+		x, _ := b.buf.Regions()
+		if len(x) == 0 {
+			return n, nil
+		}
+		m, err := w.Write(x)
+		b.canUnread = false
+		b.buf.consume(m)
+		n += int64(m)
+		if err != nil {
+			return n, err
+		}
+		if m < len(x) {
+			return n, io.ErrShortWrite
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package ringbuffer_test
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		b := ringbuffer.NewBytes(0)
+		n, err := b.Write([]byte("a"))
+		assert.Equal(0, n)
+		assert.ErrorIs(err, ringbuffer.ErrFull)
+		n, err = b.Read(make([]byte, 1))
+		assert.Equal(0, n)
+		assert.ErrorIs(err, io.EOF)
+	}
+
+	b := ringbuffer.NewBytes(8)
+	p := make([]byte, 5)
+	for i := 0; i < 5; i++ {
+		n, err := b.Write([]byte("hello"))
+		assert.NoError(err)
+		assert.Equal(5, n)
+		n, err = b.Write([]byte(" world"))
+		assert.ErrorIs(err, ringbuffer.ErrFull)
+		assert.Equal(3, n)
+		assert.Equal(0, b.Free())
+
+		n, err = b.Read(p)
+		assert.NoError(err)
+		assert.Equal("hello", string(p[:n]))
+		n, err = b.Read(p[:0])
+		assert.NoError(err)
+		assert.Equal(0, n)
+		n, err = b.Read(p)
+		assert.NoError(err)
+		assert.Equal(" wo", string(p[:n]))
+		n, err = b.Read(p)
+		assert.ErrorIs(err, io.EOF)
+		assert.Equal(0, n)
+	}
+
+	b.Write([]byte("abc"))
+	b.Reset()
+	assert.Equal(0, b.Len())
+	assert.Equal(8, b.Free())
+
+	// works with io helpers
+	io.Copy(b, strings.NewReader("stream"))
+	data, err := io.ReadAll(b)
+	assert.NoError(err)
+	assert.Equal("stream", string(data))
+}
+
+type chunkReader struct {
+	chunks []string
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks[0] = r.chunks[0][n:]
+	if r.chunks[0] == "" {
+		r.chunks = r.chunks[1:]
+	}
+	return n, nil
+}
+
+func TestBytesReadFromWriteTo(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBytes(8)
+	// move cursors so that contents wrap around
+	b.Write([]byte("12345"))
+	b.Read(make([]byte, 5))
+
+	n, err := b.ReadFrom(&chunkReader{chunks: []string{"ab", "cdef"}})
+	assert.NoError(err)
+	assert.Equal(int64(6), n)
+	n, err = b.ReadFrom(&chunkReader{chunks: []string{"ghijk"}})
+	assert.ErrorIs(err, ringbuffer.ErrFull)
+	assert.Equal(int64(2), n)
+	n, err = b.ReadFrom(strings.NewReader("x"))
+	assert.ErrorIs(err, ringbuffer.ErrFull)
+	assert.Equal(int64(0), n)
+
+	// failing writer leaves unwritten bytes in the buffer
+	n, err = b.WriteTo(&failingWriter{left: 4})
+	assert.Error(err)
+	assert.Equal(int64(4), n)
+	assert.Equal(4, b.Len())
+
+	var out strings.Builder
+	n, err = b.WriteTo(&out)
+	assert.NoError(err)
+	assert.Equal(int64(4), n)
+	assert.Equal("efgh", out.String())
+	assert.Equal(0, b.Len())
+
+	// read errors are returned
+	n, err = b.ReadFrom(iotest.ErrReader(io.ErrUnexpectedEOF))
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+	assert.Equal(int64(0), n)
+}
+
+func TestBytesByteReaderWriter(t *testing.T) {
+	assert := assert.New(t)
+
+	var _ io.ByteScanner = ringbuffer.NewBytes(0)
+	var _ io.ByteWriter = ringbuffer.NewBytes(0)
+
+	b := ringbuffer.NewBytes(3)
+	assert.ErrorIs(b.UnreadByte(), bufio.ErrInvalidUnreadByte)
+	for i := 0; i < 5; i++ {
+		assert.NoError(b.WriteByte('a'))
+		assert.NoError(b.WriteByte('b'))
+		assert.NoError(b.WriteByte('c'))
+		assert.ErrorIs(b.WriteByte('d'), ringbuffer.ErrFull)
+
+		c, err := b.ReadByte()
+		assert.NoError(err)
+		assert.Equal(byte('a'), c)
+		assert.NoError(b.UnreadByte())
+		assert.ErrorIs(b.UnreadByte(), bufio.ErrInvalidUnreadByte)
+		c, _ = b.ReadByte()
+		assert.Equal(byte('a'), c)
+
+		p := make([]byte, 2)
+		b.Read(p)
+		assert.NoError(b.UnreadByte())
+		c, _ = b.ReadByte()
+		assert.Equal(byte('c'), c)
+
+		_, err = b.ReadByte()
+		assert.ErrorIs(err, io.EOF)
+		assert.ErrorIs(b.UnreadByte(), bufio.ErrInvalidUnreadByte)
+	}
+
+	// a write may reuse the slot of the read byte
+	b.WriteByte('x')
+	b.ReadByte()
+	b.WriteByte('y')
+	assert.ErrorIs(b.UnreadByte(), bufio.ErrInvalidUnreadByte)
+}
+
+func TestBytesSlices(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		b := ringbuffer.NewBytes(0)
+		x, y := b.WriteSlices()
+		assert.Equal(0, len(x)+len(y))
+		x, y = b.ReadSlices()
+		assert.Equal(0, len(x)+len(y))
+		b.Commit(0)
+		b.Consume(0)
+	}
+
+	b := ringbuffer.NewBytes(8)
+	// move cursors so that free space wraps around
+	b.Write([]byte("12345"))
+	b.Read(make([]byte, 5))
+
+	x, y := b.WriteSlices()
+	assert.Equal(4, len(x))
+	assert.Equal(4, len(y))
+	n := copy(x, "abcd")
+	n += copy(y, "ef")
+	b.Commit(n)
+	assert.Equal(6, b.Len())
+	assert.Panics(func() { b.Commit(3) })
+
+	x, y = b.ReadSlices()
+	assert.Equal("abcd", string(x))
+	assert.Equal("ef", string(y))
+	b.Consume(5)
+	x, y = b.ReadSlices()
+	assert.Equal("f", string(x)+string(y))
+	assert.Panics(func() { b.Consume(2) })
+	assert.Panics(func() { b.Consume(-1) })
+	b.Consume(1)
+	assert.Equal(0, b.Len())
+}
+
+func TestBytesPeekDiscard(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBytes(8)
+	// move cursors so that contents wrap around
+	b.Write([]byte("12345"))
+	b.Read(make([]byte, 5))
+	b.Write([]byte("abcdef"))
+
+	p, err := b.Peek(3)
+	assert.NoError(err)
+	assert.Equal("abc", string(p))
+	p, err = b.Peek(6)
+	assert.NoError(err)
+	assert.Equal("abcdef", string(p))
+	p, err = b.Peek(10)
+	assert.ErrorIs(err, io.EOF)
+	assert.Equal("abcdef", string(p))
+	_, err = b.Peek(-1)
+	assert.ErrorIs(err, bufio.ErrNegativeCount)
+	assert.Equal(6, b.Len())
+
+	n, err := b.Discard(2)
+	assert.NoError(err)
+	assert.Equal(2, n)
+	p, _ = b.Peek(2)
+	assert.Equal("cd", string(p))
+	_, err = b.Discard(-1)
+	assert.ErrorIs(err, bufio.ErrNegativeCount)
+	n, err = b.Discard(10)
+	assert.ErrorIs(err, io.EOF)
+	assert.Equal(4, n)
+	assert.Equal(0, b.Len())
+	p, err = b.Peek(1)
+	assert.ErrorIs(err, io.EOF)
+	assert.Equal(0, len(p))
+}
+
+func TestBytesReadBytes(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBytes(12)
+	// move cursors so that contents wrap around
+	b.Write([]byte("12345678"))
+	b.Read(make([]byte, 8))
+	b.Write([]byte("ab\ncdef\ngh"))
+
+	line, err := b.ReadBytes('\n')
+	assert.NoError(err)
+	assert.Equal("ab\n", string(line))
+	s, err := b.ReadString('\n')
+	assert.NoError(err)
+	assert.Equal("cdef\n", s)
+	assert.NoError(b.UnreadByte())
+	s, _ = b.ReadString('\n')
+	assert.Equal("\n", s)
+	s, err = b.ReadString('\n')
+	assert.ErrorIs(err, io.EOF)
+	assert.Equal("gh", s)
+	line, err = b.ReadBytes('\n')
+	assert.ErrorIs(err, io.EOF)
+	assert.Equal(0, len(line))
+}
+
+func ExampleBytes_ReadString() {
+	b := ringbuffer.NewBytes(32)
+	b.Write([]byte("HELO a\r\nMAIL b\r\n"))
+	for {
+		line, err := b.ReadString('\n')
+		if err != nil {
+			break
+		}
+		fmt.Printf("%q\n", line)
+	}
+	// Output:
+	// "HELO a\r\n"
+	// "MAIL b\r\n"
+}
+
+func ExampleBytes_Peek() {
+	b := ringbuffer.NewBytes(16)
+	b.Write([]byte("GET / HTTP/1.1"))
+	method, _ := b.Peek(3)
+	fmt.Println(string(method), b.Len())
+	// Output: GET 14
+}
+
+func ExampleBytes_WriteSlices() {
+	b := ringbuffer.NewBytes(16)
+	x, _ := b.WriteSlices()
+	n, _ := strings.NewReader("from a socket").Read(x)
+	b.Commit(n)
+	x, y := b.ReadSlices()
+	fmt.Println(string(x) + string(y))
+	// Output: from a socket
+}
+
+func ExampleBytes() {
+	b := ringbuffer.NewBytes(16)
+	fmt.Fprintf(b, "x=%d", 42)
+	data, _ := io.ReadAll(b)
+	fmt.Println(string(data))
+	// Output: x=42
+}
@@ -0,0 +1,65 @@
+package ringbuffer
+
+// Start a goroutine popping elements from the buffer and sending them to the returned channel in FIFO order.
+//
+// The channel is closed once the buffer is closed and drained. The goroutine blocks on sending if nobody receives.
+func (b *Blocking[T]) ToChan() <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for {
+			v, ok := b.Pop()
+			if !ok {
+				return
+			}
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+// Receive elements from ch and push them to the buffer until ch is closed or the buffer is closed.
+//
+// When the buffer is full, the given policy decides the outcome as for a RingBuffer: Reject drops the received
+// element, DropOldest evicts the oldest element, DropNewest replaces the newest one. To wait for free space instead,
+// receive from ch and use Push. Returns the number of elements accepted.
+func (b *Blocking[T]) FromChan(ch <-chan T, policy OverflowPolicy) int {
+	n := 0
+	for v := range ch {
+		accepted, closed := b.pushPolicy(v, policy)
+		if closed {
+			break
+		}
+		if accepted {
+			n++
+		}
+	}
+	return n
+}
+
+func (b *Blocking[T]) pushPolicy(v T, policy OverflowPolicy) (accepted, closed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return false, true
+	}
+	if b.buf.Cap() == 0 {
+		return false, false
+	}
+	wasEmpty, wasFull := b.buf.IsEmpty(), b.buf.IsFull()
+	switch policy {
+	case DropOldest:
+		b.buf.PushOverwrite(v)
+	case DropNewest:
+		b.buf.PushMerge(v, func(_, v T) T { return v })
+	default:
+		if !b.buf.Push(v) {
+			return false, false
+		}
+	}
+	tap(b.mirrors, v)
+	b.notify.update(wasEmpty, wasFull, b.buf.IsEmpty(), b.buf.IsFull())
+	b.notEmpty.Signal()
+	b.changed.Broadcast()
+	return true, false
+}
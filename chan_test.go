@@ -0,0 +1,78 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestToChan(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBlocking[int](4)
+	ch := b.ToChan()
+	go func() {
+		for i := 1; i <= 10; i++ {
+			b.Push(i)
+		}
+		b.Close()
+	}()
+	var out []int
+	for v := range ch {
+		out = append(out, v)
+	}
+	assert.Equal([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, out)
+}
+
+func TestFromChan(t *testing.T) {
+	assert := assert.New(t)
+
+	send := func(vs ...int) <-chan int {
+		ch := make(chan int, len(vs))
+		for _, v := range vs {
+			ch <- v
+		}
+		close(ch)
+		return ch
+	}
+	drain := func(b *ringbuffer.Blocking[int]) []int {
+		var out []int
+		for {
+			v, ok := b.TryPop()
+			if !ok {
+				return out
+			}
+			out = append(out, v)
+		}
+	}
+
+	b := ringbuffer.NewBlocking[int](3)
+	assert.Equal(3, b.FromChan(send(1, 2, 3, 4, 5), ringbuffer.Reject))
+	assert.Equal([]int{1, 2, 3}, drain(b))
+	assert.Equal(5, b.FromChan(send(1, 2, 3, 4, 5), ringbuffer.DropOldest))
+	assert.Equal([]int{3, 4, 5}, drain(b))
+	assert.Equal(5, b.FromChan(send(1, 2, 3, 4, 5), ringbuffer.DropNewest))
+	assert.Equal([]int{1, 2, 5}, drain(b))
+
+	assert.Equal(0, ringbuffer.NewBlocking[int](0).FromChan(send(1), ringbuffer.DropOldest))
+
+	// stops receiving once the buffer is closed
+	b.Close()
+	ch := send(1, 2)
+	assert.Equal(0, b.FromChan(ch, ringbuffer.Reject))
+	assert.Equal(1, len(ch))
+}
+
+func ExampleBlocking_ToChan() {
+	b := ringbuffer.NewBlocking[string](2)
+	b.Push("hello")
+	b.Push("world")
+	b.Close()
+	for v := range b.ToChan() {
+		fmt.Println(v)
+	}
+	// Output:
+	// hello
+	// world
+}
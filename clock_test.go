@@ -0,0 +1,37 @@
+package ringbuffer_test
+
+import (
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestManualClock(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Unix(1000, 0)
+	c := ringbuffer.NewManualClock(start)
+	assert.Equal(start, c.Now())
+
+	now := c.After(0)
+	soon := c.After(time.Second)
+	later := c.After(time.Minute)
+	assert.Equal(start, <-now)
+
+	c.Advance(time.Second)
+	assert.Equal(start.Add(time.Second), c.Now())
+	assert.Equal(start.Add(time.Second), <-soon)
+	select {
+	case <-later:
+		t.Fail()
+	default:
+	}
+
+	c.Advance(time.Hour)
+	assert.Equal(start.Add(time.Hour+time.Second), <-later)
+
+	var sys ringbuffer.Clock = ringbuffer.SystemClock{}
+	assert.WithinDuration(time.Now(), sys.Now(), time.Minute)
+	<-sys.After(time.Millisecond)
+}
@@ -0,0 +1,60 @@
+package ringbuffer
+
+// Fixed length FIFO queue of keyed updates.
+//
+// Pushing a key which is already pending replaces its value instead of occupying a new slot. The key keeps its
+// original position in the queue, only the value is updated. Useful for state synchronization where superseded
+// updates are worthless.
+type Coalescer[K comparable, V any] struct {
+	keys   RingBuffer[K]
+	values map[K]V
+}
+
+// Create a new coalescer which can store capacity distinct pending keys.
+func NewCoalescer[K comparable, V any](capacity int) Coalescer[K, V] {
+	return Coalescer[K, V]{
+		keys:   New[K](capacity),
+		values: make(map[K]V),
+	}
+}
+
+// How many distinct keys a coalescer can store?
+func (c Coalescer[K, V]) Cap() int {
+	return c.keys.Cap()
+}
+
+// How many distinct keys are currently pending?
+func (c Coalescer[K, V]) Len() int {
+	return c.keys.Len()
+}
+
+// Push an update for the key.
+//
+// If the key is already pending, its value is replaced and its position is preserved. Returns true on success. Returns
+// false if the key is not pending and there is no free space.
+func (c *Coalescer[K, V]) Push(key K, v V) bool {
+	if _, ok := c.values[key]; ok {
+		c.values[key] = v
+		return true
+	}
+	if !c.keys.Push(key) {
+		return false
+	}
+	c.values[key] = v
+	return true
+}
+
+// Try to pop the oldest pending update.
+//
+// Returns the key, its latest value and true on success. Returns default values and false if there were no pending
+// updates.
+func (c *Coalescer[K, V]) Pop() (K, V, bool) {
+	key, ok := c.keys.Pop()
+	if !ok {
+		var def V
+		return key, def, false
+	}
+	v := c.values[key]
+	delete(c.values, key)
+	return key, v, true
+}
@@ -0,0 +1,54 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCoalescer(t *testing.T) {
+	assert := assert.New(t)
+
+	eq3 := func(k string, v int, ok bool) func(expectedK string, expectedV int, expectedOk bool) {
+		return func(expectedK string, expectedV int, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedK, k)
+			assert.Equal(expectedV, v)
+		}
+	}
+
+	{
+		var c ringbuffer.Coalescer[string, int]
+		assert.Equal(false, c.Push("a", 1))
+		assert.Equal(0, c.Cap())
+		assert.Equal(0, c.Len())
+		eq3(c.Pop())("", 0, false)
+	}
+
+	c := ringbuffer.NewCoalescer[string, int](2)
+	assert.Equal(2, c.Cap())
+	assert.Equal(true, c.Push("a", 1))
+	assert.Equal(true, c.Push("b", 2))
+	assert.Equal(false, c.Push("c", 3))
+	assert.Equal(true, c.Push("a", 4))
+	assert.Equal(2, c.Len())
+
+	eq3(c.Pop())("a", 4, true)
+	assert.Equal(true, c.Push("a", 5))
+	eq3(c.Pop())("b", 2, true)
+	eq3(c.Pop())("a", 5, true)
+	eq3(c.Pop())("", 0, false)
+	assert.Equal(0, c.Len())
+}
+
+func ExampleCoalescer() {
+	c := ringbuffer.NewCoalescer[string, int](5)
+	c.Push("x", 1)
+	c.Push("y", 2)
+	c.Push("x", 3)
+	k1, v1, _ := c.Pop()
+	k2, v2, _ := c.Pop()
+	fmt.Printf("%s=%d %s=%d\n", k1, v1, k2, v2)
+	// Output: x=3 y=2
+}
@@ -0,0 +1,69 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// Encoder and decoder of individual elements, used to stream buffer contents to and from io endpoints.
+type Codec[T any] interface {
+	Encode(w io.Writer, v T) error
+	Decode(r io.Reader) (T, error)
+}
+
+// Codec for fixed-size values in encoding/binary format. Little endian byte order is used if Order is nil.
+type BinaryCodec[T any] struct {
+	Order binary.ByteOrder
+}
+
+func (c BinaryCodec[T]) order() binary.ByteOrder {
+	if c.Order == nil {
+		return binary.LittleEndian
+	}
+	return c.Order
+}
+
+func (c BinaryCodec[T]) Encode(w io.Writer, v T) error {
+	return binary.Write(w, c.order(), v)
+}
+
+func (c BinaryCodec[T]) Decode(r io.Reader) (T, error) {
+	var v T
+	err := binary.Read(r, c.order(), &v)
+	return v, err
+}
+
+// Pop elements and encode them to w one by one, until the buffer is empty.
+//
+// Returns the number of elements written. An element is popped only after it was encoded successfully, on error it
+// stays in the buffer.
+func (b *RingBuffer[T]) WriteElements(w io.Writer, enc Codec[T]) (int, error) {
+	n := 0
+	for b.Len() > 0 {
+		if err := enc.Encode(w, b.buffer[b.read]); err != nil {
+			return n, err
+		}
+		b.Pop()
+		n++
+	}
+	return n, nil
+}
+
+// Decode elements from r one by one and push them to the buffer, until it is full or r is exhausted.
+//
+// Returns the number of elements read. Reaching io.EOF between elements is not an error.
+func (b *RingBuffer[T]) ReadElements(r io.Reader, dec Codec[T]) (int, error) {
+	n := 0
+	for b.Len() < b.Cap() {
+		v, err := dec.Decode(r)
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+		b.Push(v)
+		n++
+	}
+	return n, nil
+}
@@ -0,0 +1,78 @@
+package ringbuffer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+type failingWriter struct {
+	left int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.left < len(p) {
+		return 0, errors.New("disk full")
+	}
+	w.left -= len(p)
+	return len(p), nil
+}
+
+func TestCodec(t *testing.T) {
+	assert := assert.New(t)
+	codec := ringbuffer.BinaryCodec[int32]{}
+
+	src := ringbuffer.New[int32](4)
+	src.Push(1)
+	src.Push(2)
+	src.Push(3)
+	var out bytes.Buffer
+	n, err := src.WriteElements(&out, codec)
+	assert.NoError(err)
+	assert.Equal(3, n)
+	assert.Equal(0, src.Len())
+	assert.Equal(12, out.Len())
+
+	dst := ringbuffer.New[int32](2)
+	n, err = dst.ReadElements(&out, codec)
+	assert.NoError(err)
+	assert.Equal(2, n)
+	assert.Equal(4, out.Len())
+	dst.Pop()
+	n, err = dst.ReadElements(&out, codec)
+	assert.NoError(err)
+	assert.Equal(1, n)
+	n, err = dst.ReadElements(&out, codec)
+	assert.NoError(err)
+	assert.Equal(0, n)
+
+	// truncated stream
+	dst.Pop()
+	n, err = dst.ReadElements(bytes.NewReader([]byte{1, 2}), codec)
+	assert.Equal(0, n)
+	assert.ErrorIs(err, io.ErrUnexpectedEOF)
+
+	// failing writer leaves unwritten elements in the buffer
+	dst.Push(4)
+	n, err = dst.WriteElements(&failingWriter{left: 4}, ringbuffer.BinaryCodec[int32]{Order: binary.BigEndian})
+	assert.Error(err)
+	assert.Equal(1, n)
+	assert.Equal(1, dst.Len())
+	v, _ := dst.Pop()
+	assert.Equal(int32(4), v)
+}
+
+func ExampleRingBuffer_WriteElements() {
+	b := ringbuffer.New[uint16](5)
+	b.Push(1)
+	b.Push(2)
+	var out bytes.Buffer
+	b.WriteElements(&out, ringbuffer.BinaryCodec[uint16]{Order: binary.BigEndian})
+	fmt.Printf("%x\n", out.Bytes())
+	// Output: 00010002
+}
@@ -0,0 +1,100 @@
+package ringbuffer
+
+import (
+	"iter"
+	"time"
+)
+
+// Merge two individually sorted buffers into dst, keeping the result sorted according to cmp.
+//
+// Elements are popped from a and b as they are pushed to dst. When elements compare equal, the one from a goes first.
+// Merging stops when both sources are empty or dst is full, whatever is left stays in the sources. Returns how many
+// elements were pushed to dst.
+func MergeSorted[T any](a, b *RingBuffer[T], cmp func(T, T) int, dst *RingBuffer[T]) int {
+	n := 0
+	for dst.Len() < dst.Cap() {
+		src := a
+		if a.Len() == 0 {
+			src = b
+		} else if b.Len() > 0 && cmp(*b.slot(0), *a.slot(0)) < 0 {
+			src = b
+		}
+		v, ok := src.Pop()
+		if !ok {
+			break
+		}
+		dst.Push(v)
+		n++
+	}
+	return n
+}
+
+// Move elements from a and b into dst alternately, starting with a.
+//
+// Elements are moved in pairs, one from each source, so that the output never ends with a lone element. Interleaving
+// stops when either source is empty or dst has no room for another pair. Returns how many elements were pushed to
+// dst.
+func Interleave[T any](a, b, dst *RingBuffer[T]) int {
+	n := 0
+	for a.Len() > 0 && b.Len() > 0 && dst.Cap()-dst.Len() >= 2 {
+		va, _ := a.Pop()
+		vb, _ := b.Pop()
+		dst.Push(va)
+		dst.Push(vb)
+		n += 2
+	}
+	return n
+}
+
+// Iterate over pairs of elements from a and b in FIFO order, without removing them.
+//
+// Iteration stops at the end of the shorter buffer. The buffers must not be modified during iteration.
+func Zip[A, B any](a *RingBuffer[A], b *RingBuffer[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		n := min(a.Len(), b.Len())
+		for i := 0; i < n; i++ {
+			if !yield(*a.slot(i), *b.slot(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over pairs of elements from a and b with timestamps no further than tol apart, without removing them.
+//
+// Both buffers must be sorted by timestamp. Each element is paired at most once, with the closest candidate from the
+// other buffer. Elements without a partner within tolerance are skipped. The buffers must not be modified during
+// iteration.
+func JoinAligned[A, B any](a *RingBuffer[A], b *RingBuffer[B], tsA func(A) time.Time, tsB func(B) time.Time, tol time.Duration) iter.Seq2[A, B] {
+	dist := func(x, y time.Time) time.Duration {
+		d := x.Sub(y)
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	return func(yield func(A, B) bool) {
+		na, nb := a.Len(), b.Len()
+		for i, j := 0, 0; i < na && j < nb; {
+			va, vb := *a.slot(i), *b.slot(j)
+			ta, tb := tsA(va), tsB(vb)
+			d := dist(ta, tb)
+			switch {
+			case d > tol && ta.Before(tb):
+				i++
+			case d > tol:
+				j++
+			case i+1 < na && dist(tsA(*a.slot(i + 1)), tb) < d:
+				i++
+			case j+1 < nb && dist(ta, tsB(*b.slot(j + 1))) < d:
+				j++
+			default:
+				if !yield(va, vb) {
+					return
+				}
+				i++
+				j++
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package ringbuffer_test
+
+import (
+	"cmp"
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func fill[T any](capacity int, vs ...T) ringbuffer.RingBuffer[T] {
+	b := ringbuffer.New[T](capacity)
+	for _, v := range vs {
+		b.Push(v)
+	}
+	return b
+}
+
+func TestMergeSorted(t *testing.T) {
+	assert := assert.New(t)
+
+	type ev struct {
+		ts  int
+		src string
+	}
+	byTs := func(x, y ev) int { return cmp.Compare(x.ts, y.ts) }
+
+	a := fill(5, ev{1, "a"}, ev{3, "a"}, ev{5, "a"})
+	b := fill(5, ev{2, "b"}, ev{3, "b"}, ev{6, "b"}, ev{7, "b"})
+	dst := ringbuffer.New[ev](5)
+	assert.Equal(5, ringbuffer.MergeSorted(&a, &b, byTs, &dst))
+	assert.Equal([]ev{{1, "a"}, {2, "b"}, {3, "a"}, {3, "b"}, {5, "a"}}, contents(&dst))
+	assert.Equal(0, a.Len())
+	assert.Equal(2, b.Len())
+
+	dst = ringbuffer.New[ev](5)
+	assert.Equal(2, ringbuffer.MergeSorted(&a, &b, byTs, &dst))
+	assert.Equal([]ev{{6, "b"}, {7, "b"}}, contents(&dst))
+
+	var empty ringbuffer.RingBuffer[ev]
+	assert.Equal(0, ringbuffer.MergeSorted(&empty, &empty, byTs, &dst))
+}
+
+func ExampleMergeSorted() {
+	a := fill(3, 1, 4, 7)
+	b := fill(3, 2, 3, 9)
+	dst := ringbuffer.New[int](6)
+	ringbuffer.MergeSorted(&a, &b, cmp.Compare[int], &dst)
+	for dst.Len() > 0 {
+		v, _ := dst.Pop()
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+	// Output: 1 2 3 4 7 9
+}
+
+func TestInterleave(t *testing.T) {
+	assert := assert.New(t)
+
+	left := fill(4, 1, 3, 5, 7)
+	right := fill(4, 2, 4, 6)
+	dst := ringbuffer.New[int](5)
+	assert.Equal(4, ringbuffer.Interleave(&left, &right, &dst))
+	assert.Equal([]int{1, 2, 3, 4}, contents(&dst))
+	dst.Pop()
+	assert.Equal(2, ringbuffer.Interleave(&left, &right, &dst))
+	assert.Equal([]int{2, 3, 4, 5, 6}, contents(&dst))
+	assert.Equal(0, ringbuffer.Interleave(&left, &right, &dst))
+	assert.Equal(1, left.Len())
+	assert.Equal(0, right.Len())
+}
+
+func TestZip(t *testing.T) {
+	assert := assert.New(t)
+
+	nums := fill(5, 1, 2, 3)
+	names := fill(5, "one", "two")
+	var pairs []string
+	for n, s := range ringbuffer.Zip(&nums, &names) {
+		pairs = append(pairs, fmt.Sprintf("%d=%s", n, s))
+	}
+	assert.Equal([]string{"1=one", "2=two"}, pairs)
+	assert.Equal(3, nums.Len())
+
+	for range ringbuffer.Zip(&nums, &names) {
+		break
+	}
+
+	var empty ringbuffer.RingBuffer[string]
+	for range ringbuffer.Zip(&nums, &empty) {
+		t.Fail()
+	}
+}
+
+func ExampleInterleave() {
+	left := fill(3, "l1", "l2", "l3")
+	right := fill(3, "r1", "r2", "r3")
+	out := ringbuffer.New[string](6)
+	ringbuffer.Interleave(&left, &right, &out)
+	fmt.Println(contents(&out))
+	// Output: [l1 r1 l2 r2 l3 r3]
+}
+
+type sample struct {
+	at time.Duration
+	v  int
+}
+
+func TestJoinAligned(t *testing.T) {
+	assert := assert.New(t)
+
+	base := time.Unix(0, 0)
+	ts := func(s sample) time.Time { return base.Add(s.at) }
+	a := fill(10,
+		sample{0, 1},
+		sample{10 * time.Millisecond, 2},
+		sample{12 * time.Millisecond, 3},
+		sample{40 * time.Millisecond, 4},
+		sample{60 * time.Millisecond, 5},
+	)
+	b := fill(10,
+		sample{1 * time.Millisecond, 10},
+		sample{13 * time.Millisecond, 30},
+		sample{25 * time.Millisecond, 99},
+		sample{58 * time.Millisecond, 50},
+		sample{59 * time.Millisecond, 51},
+	)
+	var got [][2]int
+	for x, y := range ringbuffer.JoinAligned(&a, &b, ts, ts, 3*time.Millisecond) {
+		got = append(got, [2]int{x.v, y.v})
+	}
+	assert.Equal([][2]int{{1, 10}, {3, 30}, {5, 51}}, got)
+	assert.Equal(5, a.Len())
+
+	for range ringbuffer.JoinAligned(&a, &b, ts, ts, time.Hour) {
+		break
+	}
+}
@@ -0,0 +1,42 @@
+package ringbuffer
+
+// Fixed length FIFO queue which conflates elements with equal keys, built on Coalescer.
+//
+// The key of an element is computed by a user function. Pushing an element whose key is already pending replaces the
+// pending element in place, so the queue keeps the FIFO order of first appearance of each key while always delivering
+// the latest element for it. Typical for market data feeds, where only the last quote per instrument matters.
+type Conflating[T any, K comparable] struct {
+	c   Coalescer[K, T]
+	key func(T) K
+}
+
+// Create a new queue which can store capacity distinct pending keys, computed from elements with key.
+func NewConflating[T any, K comparable](capacity int, key func(T) K) Conflating[T, K] {
+	return Conflating[T, K]{c: NewCoalescer[K, T](capacity), key: key}
+}
+
+// How many distinct keys a queue can store?
+func (q Conflating[T, K]) Cap() int {
+	return q.c.Cap()
+}
+
+// How many distinct keys are currently pending?
+func (q Conflating[T, K]) Len() int {
+	return q.c.Len()
+}
+
+// Push a new element to the queue.
+//
+// If an element with the same key is pending, it is replaced and keeps its position. Returns true on success.
+// Returns false if the key is not pending and there is no free space.
+func (q *Conflating[T, K]) Push(v T) bool {
+	return q.c.Push(q.key(v), v)
+}
+
+// Try to pop the element for the oldest pending key.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the queue.
+func (q *Conflating[T, K]) Pop() (T, bool) {
+	_, v, ok := q.c.Pop()
+	return v, ok
+}
@@ -0,0 +1,51 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type quote struct {
+	symbol string
+	price  int
+}
+
+func TestConflating(t *testing.T) {
+	assert := assert.New(t)
+
+	q := ringbuffer.NewConflating(2, func(v quote) string { return v.symbol })
+	assert.Equal(2, q.Cap())
+	assert.Equal(true, q.Push(quote{"AAA", 1}))
+	assert.Equal(true, q.Push(quote{"BBB", 2}))
+	assert.Equal(true, q.Push(quote{"AAA", 3}))
+	assert.Equal(false, q.Push(quote{"CCC", 4}))
+	assert.Equal(2, q.Len())
+
+	// order of first appearance, latest element
+	v, ok := q.Pop()
+	assert.Equal(true, ok)
+	assert.Equal(quote{"AAA", 3}, v)
+	assert.Equal(true, q.Push(quote{"AAA", 5}))
+	v, _ = q.Pop()
+	assert.Equal(quote{"BBB", 2}, v)
+	v, _ = q.Pop()
+	assert.Equal(quote{"AAA", 5}, v)
+	_, ok = q.Pop()
+	assert.Equal(false, ok)
+}
+
+func ExampleConflating() {
+	q := ringbuffer.NewConflating(8, func(v quote) string { return v.symbol })
+	q.Push(quote{"AAA", 100})
+	q.Push(quote{"BBB", 200})
+	q.Push(quote{"AAA", 101})
+	for q.Len() > 0 {
+		v, _ := q.Pop()
+		fmt.Println(v.symbol, v.price)
+	}
+	// Output:
+	// AAA 101
+	// BBB 200
+}
@@ -0,0 +1,124 @@
+package ringbuffer
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Fixed length FIFO ring buffer which tracks an explicit element count instead of reserving a slot.
+//
+// Unlike RingBuffer, capacity equals the length of the backing slice, no element is wasted. Worth it when T is large,
+// at the cost of updating two integers on every push or pop.
+type Counted[T any] struct {
+	read   int
+	count  int
+	buffer []T
+}
+
+// Create a new buffer which can store capacity elements. The buffer is fixed in length and will not grow.
+func NewCounted[T any](capacity int) Counted[T] {
+	var buffer []T
+	if capacity >= 1 {
+		buffer = make([]T, capacity)
+	}
+	return Counted[T]{buffer: buffer}
+}
+
+// How many elements a buffer can store?
+func (b Counted[T]) Cap() int {
+	return len(b.buffer)
+}
+
+// How many elements are currently stored in the buffer?
+func (b Counted[T]) Len() int {
+	return b.count
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *Counted[T]) Push(v T) bool {
+	return CountedPush(b.buffer, b.read, &b.count, v)
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *Counted[T]) Pop() (T, bool) {
+	return CountedPop(b.buffer, &b.read, &b.count)
+}
+
+// Look at the oldest element without removing it from the buffer.
+//
+// Returns the oldest element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b Counted[T]) Peek() (T, bool) {
+	return CountedAt(b.buffer, b.read, b.count, 0)
+}
+
+// Look at the most recently pushed element without removing it from the buffer.
+//
+// Returns the newest element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b Counted[T]) Back() (T, bool) {
+	return CountedAt(b.buffer, b.read, b.count, b.count-1)
+}
+
+// Get the element at logical index i, 0 being the oldest element.
+//
+// Returns the element and true on success. Returns default value and false if i is out of range.
+func (b Counted[T]) At(i int) (T, bool) {
+	return CountedAt(b.buffer, b.read, b.count, i)
+}
+
+// Get stored elements in FIFO order as (up to) two contiguous regions of the underlying buffer.
+//
+// The second region is empty unless stored elements wrap around the end of the buffer.
+func (b Counted[T]) Regions() ([]T, []T) {
+	return CountedRegions(b.buffer, b.read, b.count)
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func CountedPush[T any, U constraints.Integer](slice []T, read U, count *U, v T) bool {
+	if int(*count) >= len(slice) {
+		return false // no more space
+	}
+	slice[(int(read)+int(*count))%len(slice)] = v
+	*count++
+	return true
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func CountedPop[T any, U constraints.Integer](slice []T, read, count *U) (T, bool) {
+	if *count == 0 {
+		var def T
+		return def, false
+	}
+	val := slice[*read]
+	*read = U((int(*read) + 1) % len(slice))
+	*count--
+	return val, true
+}
+
+// Get the element at logical index i, 0 being the oldest element.
+//
+// Returns the element and true on success. Returns default value and false if i is out of range.
+func CountedAt[T any, U constraints.Integer](slice []T, read, count U, i int) (T, bool) {
+	if i < 0 || i >= int(count) {
+		var def T
+		return def, false
+	}
+	return slice[(int(read)+i)%len(slice)], true
+}
+
+// Get stored elements in FIFO order as (up to) two contiguous regions of the slice.
+//
+// The second region is empty unless stored elements wrap around the end of the slice.
+func CountedRegions[T any, U constraints.Integer](slice []T, read, count U) ([]T, []T) {
+	end := int(read) + int(count)
+	if end <= len(slice) {
+		return slice[read:end], nil
+	}
+	return slice[read:], slice[:end-len(slice)]
+}
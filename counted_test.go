@@ -0,0 +1,77 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCounted(t *testing.T) {
+	assert := assert.New(t)
+
+	eq2 := func(v int, ok bool) func(expectedV int, expectedOk bool) {
+		return func(expectedV int, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedV, v)
+		}
+	}
+
+	{
+		var buf ringbuffer.Counted[int]
+		assert.Equal(0, buf.Cap())
+		assert.Equal(false, buf.Push(1))
+		eq2(buf.Pop())(0, false)
+		eq2(buf.Peek())(0, false)
+		eq2(buf.Back())(0, false)
+	}
+
+	buf := ringbuffer.NewCounted[int](3)
+	assert.Equal(3, buf.Cap())
+	for i := 0; i < 5; i++ {
+		assert.Equal(true, buf.Push(1))
+		assert.Equal(true, buf.Push(2))
+		assert.Equal(true, buf.Push(3))
+		assert.Equal(false, buf.Push(4))
+		assert.Equal(3, buf.Len())
+		eq2(buf.Peek())(1, true)
+		eq2(buf.Back())(3, true)
+		eq2(buf.At(1))(2, true)
+		eq2(buf.At(3))(0, false)
+		eq2(buf.Pop())(1, true)
+		assert.Equal(true, buf.Push(4))
+
+		x, y := buf.Regions()
+		assert.Equal([]int{2, 3, 4}, append(append([]int(nil), x...), y...))
+
+		eq2(buf.Pop())(2, true)
+		eq2(buf.Pop())(3, true)
+		eq2(buf.Pop())(4, true)
+		eq2(buf.Pop())(0, false)
+		assert.Equal(0, buf.Len())
+	}
+}
+
+func ExampleCounted() {
+	type frame struct {
+		pixels [64]byte
+	}
+	b := ringbuffer.NewCounted[frame](2)
+	b.Push(frame{})
+	b.Push(frame{})
+	fmt.Println(b.Push(frame{}), b.Len(), b.Cap())
+	// Output: false 2 2
+}
+
+func ExampleCountedPush() {
+	var buf [4]int
+	var read uint8
+	var count uint8
+
+	for i := 1; i <= 5; i++ {
+		ringbuffer.CountedPush(buf[:], read, &count, i)
+	}
+	v, _ := ringbuffer.CountedPop(buf[:], &read, &count)
+	fmt.Println(v, count)
+	// Output: 1 3
+}
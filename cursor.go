@@ -0,0 +1,116 @@
+package ringbuffer
+
+// Position within a RingBuffer for walking stored elements in FIFO order while modifying the buffer.
+//
+// A cursor is either on an element or between two elements. A new cursor is before the first element, Next moves it
+// onto the following element. Deleting the current element leaves the cursor between its neighbours, so that the
+// following Next visits the element after the deleted one. Modifying the buffer other than through the cursor
+// invalidates it.
+type Cursor[T any] struct {
+	b   *RingBuffer[T]
+	pos int // logical index of the current element, or of the next one when not on an element
+	on  bool
+}
+
+// Create a cursor positioned before the first element of the buffer.
+func (b *RingBuffer[T]) Cursor() *Cursor[T] {
+	return &Cursor[T]{b: b}
+}
+
+// Move the cursor onto the next element.
+//
+// Returns true on success. Returns false if there are no more elements.
+func (c *Cursor[T]) Next() bool {
+	if c.on {
+		c.pos++
+	}
+	c.on = c.pos < c.b.Len()
+	return c.on
+}
+
+// Get the current element. Returns default value if the cursor is not on an element.
+func (c *Cursor[T]) Value() T {
+	if !c.on {
+		var def T
+		return def
+	}
+	return *c.b.slot(c.pos)
+}
+
+// Remove the current element from the buffer.
+//
+// Returns true on success. Returns false if the cursor is not on an element.
+func (c *Cursor[T]) Delete() bool {
+	if !c.on {
+		return false
+	}
+	c.b.remove(c.pos)
+	c.on = false
+	return true
+}
+
+// Insert a new element in front of the cursor position. The cursor stays on the same element.
+//
+// Returns true on success. Returns false if there is no free space and insert failed.
+func (c *Cursor[T]) InsertBefore(v T) bool {
+	if !c.b.insert(c.pos, v) {
+		return false
+	}
+	c.pos++
+	return true
+}
+
+// Get a pointer to the storage of the element at logical index i, 0 being the oldest element.
+func (b *RingBuffer[T]) slot(i int) *T {
+	return &b.buffer[(b.read+i)%len(b.buffer)]
+}
+
+// Remove the element at logical index i, shifting whichever side of the buffer is shorter.
+func (b *RingBuffer[T]) remove(i int) {
+	var def T
+	n := b.Len()
+	if i == 0 {
+		b.seq++
+	} else {
+		b.gen++
+	}
+	if i < n-1-i {
+		for j := i; j > 0; j-- {
+			*b.slot(j) = *b.slot(j - 1)
+		}
+		*b.slot(0) = def
+		b.read = (b.read + 1) % len(b.buffer)
+	} else {
+		for j := i; j < n-1; j++ {
+			*b.slot(j) = *b.slot(j + 1)
+		}
+		*b.slot(n - 1) = def
+		b.write = (b.write - 1 + len(b.buffer)) % len(b.buffer)
+	}
+}
+
+// Insert an element at logical index i, shifting whichever side of the buffer is shorter.
+//
+// Returns false if there is no free space.
+func (b *RingBuffer[T]) insert(i int, v T) bool {
+	n := b.Len()
+	if n == b.Cap() {
+		return false
+	}
+	if i < n {
+		b.gen++
+	}
+	if i < n-i {
+		b.read = (b.read - 1 + len(b.buffer)) % len(b.buffer)
+		for j := 0; j < i; j++ {
+			*b.slot(j) = *b.slot(j + 1)
+		}
+	} else {
+		b.write = (b.write + 1) % len(b.buffer)
+		for j := n; j > i; j-- {
+			*b.slot(j) = *b.slot(j - 1)
+		}
+	}
+	*b.slot(i) = v
+	return true
+}
@@ -0,0 +1,93 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func contents[T any](b *ringbuffer.RingBuffer[T]) []T {
+	var out []T
+	c := b.Cursor()
+	for c.Next() {
+		out = append(out, c.Value())
+	}
+	return out
+}
+
+func TestCursor(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		c := buf.Cursor()
+		assert.Equal(false, c.Next())
+		assert.Equal(0, c.Value())
+		assert.Equal(false, c.Delete())
+		assert.Equal(false, c.InsertBefore(1))
+	}
+
+	buf := ringbuffer.New[int](6)
+	// move cursors so that contents wrap around
+	for i := 0; i < 4; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	for i := 1; i <= 5; i++ {
+		buf.Push(i)
+	}
+
+	c := buf.Cursor()
+	for c.Next() {
+		if c.Value()%2 == 0 {
+			assert.Equal(true, c.Delete())
+			assert.Equal(false, c.Delete())
+		}
+	}
+	assert.Equal([]int{1, 3, 5}, contents(&buf))
+
+	c = buf.Cursor()
+	assert.Equal(true, c.InsertBefore(0))
+	for c.Next() {
+		assert.Equal(c.Value() != 5, c.InsertBefore(c.Value()*10))
+	}
+	assert.Equal(false, c.InsertBefore(7))
+	assert.Equal([]int{0, 10, 1, 30, 3, 5}, contents(&buf))
+
+	// insert at the end
+	buf.Pop()
+	c = buf.Cursor()
+	for c.Next() {
+	}
+	assert.Equal(true, c.InsertBefore(7))
+	assert.Equal([]int{10, 1, 30, 3, 5, 7}, contents(&buf))
+
+	// delete everything, head and tail
+	c = buf.Cursor()
+	for c.Next() {
+		c.Delete()
+	}
+	assert.Equal(0, buf.Len())
+	_, ok := buf.Pop()
+	assert.Equal(false, ok)
+}
+
+func ExampleCursor() {
+	b := ringbuffer.New[int](5)
+	for i := 1; i <= 5; i++ {
+		b.Push(i)
+	}
+	c := b.Cursor()
+	for c.Next() {
+		if c.Value()%2 == 0 {
+			c.Delete()
+		}
+	}
+	for b.Len() > 0 {
+		v, _ := b.Pop()
+		fmt.Print(v, " ")
+	}
+	fmt.Println()
+	// Output: 1 3 5
+}
@@ -0,0 +1,127 @@
+package ringbuffer
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Buffer state exposed by DebugHandler. Buffer types of this package implement it.
+type Inspector interface {
+	Len() int
+	Cap() int
+}
+
+// Optional interface of Inspectors which can show their oldest elements.
+type Sampler interface {
+	Sample(n int) []any
+}
+
+// Get up to n oldest elements without removing them.
+func (b RingBuffer[T]) Sample(n int) []any {
+	n = min(n, b.Len())
+	out := make([]any, 0, max(n, 0))
+	for i := 0; i < n; i++ {
+		out = append(out, *b.slot(i))
+	}
+	return out
+}
+
+// How many elements String shows at most.
+const stringLimit = 16
+
+// Format occupancy and elements in FIFO order, e.g. "RingBuffer[3/8]{1, 2, 3}". Only the oldest elements are shown
+// for large buffers.
+func (b RingBuffer[T]) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "RingBuffer[%d/%d]{", b.Len(), b.Cap())
+	for i := range min(b.Len(), stringLimit) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprint(&sb, *b.slot(i))
+	}
+	if b.Len() > stringLimit {
+		fmt.Fprintf(&sb, ", ... %d more", b.Len()-stringLimit)
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+type debugState struct {
+	Name   string  `json:"-"`
+	Len    int     `json:"len"`
+	Cap    int     `json:"cap"`
+	Rates  *Rates  `json:"rates,omitempty"`
+	Drops  *uint64 `json:"drops,omitempty"`
+	Sample []any   `json:"sample,omitempty"`
+
+	Subscribers []SubscriberStats `json:"subscribers,omitempty"`
+}
+
+var debugPage = template.Must(template.New("ringbuffers").Parse(`<!DOCTYPE html>
+<html><head><title>ring buffers</title></head><body>
+<table border="1">
+<tr><th>name</th><th>len</th><th>cap</th><th>push/s</th><th>pop/s</th><th>drops</th><th>sample</th></tr>
+{{range .}}{{$name := .Name}}<tr><td>{{.Name}}</td><td>{{.Len}}</td><td>{{.Cap}}</td>
+<td>{{with .Rates}}{{printf "%.2f" .Push}}{{end}}</td><td>{{with .Rates}}{{printf "%.2f" .Pop}}{{end}}</td>
+<td>{{with .Drops}}{{.}}{{end}}</td><td>{{range .Sample}}{{.}} {{end}}</td></tr>
+{{range .Subscribers}}<tr><td>{{$name}} / {{.Name}}</td><td>{{.Lag}}</td><td></td><td></td><td></td><td>{{.Missed}}</td>
+<td>last read {{.LastRead.Format "2006-01-02T15:04:05.000Z07:00"}}</td></tr>
+{{end}}{{end}}</table>
+</body></html>
+`))
+
+// Create an HTTP handler serving the state of named buffers, meant to be mounted under /debug/ringbuffers.
+//
+// Responds with a JSON object keyed by buffer name, or with an HTML table if the "format" query parameter is "html".
+// Besides length and capacity, rates are reported for Inspectors with a Rates method and drop counts for those with a
+// Drops method, per-subscriber lag, lost elements and last read time for those with a Subscribers method, like
+// Broadcast. The "sample" query parameter asks Samplers for up to that many oldest elements.
+//
+// Inspectors are queried from the HTTP server goroutine. Buffers which are modified concurrently must be wrapped in
+// an Inspector which takes care of synchronization.
+func DebugHandler(named map[string]Inspector) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sample, _ := strconv.Atoi(r.URL.Query().Get("sample"))
+		states := make([]debugState, 0, len(named))
+		for name, in := range named {
+			s := debugState{Name: name, Len: in.Len(), Cap: in.Cap()}
+			if m, ok := in.(interface{ Rates() Rates }); ok {
+				rates := m.Rates()
+				s.Rates = &rates
+			}
+			if m, ok := in.(interface{ Drops() uint64 }); ok {
+				drops := m.Drops()
+				s.Drops = &drops
+			}
+			if m, ok := in.(interface{ Subscribers() []SubscriberStats }); ok {
+				s.Subscribers = m.Subscribers()
+			}
+			if m, ok := in.(Sampler); ok && sample > 0 {
+				s.Sample = m.Sample(sample)
+			}
+			states = append(states, s)
+		}
+		slices.SortFunc(states, func(a, b debugState) int {
+			return cmp.Compare(a.Name, b.Name)
+		})
+
+		if r.URL.Query().Get("format") == "html" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			debugPage.Execute(w, states)
+			return
+		}
+		byName := make(map[string]debugState, len(states))
+		for _, s := range states {
+			byName[s.Name] = s
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(byName)
+	})
+}
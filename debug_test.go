@@ -0,0 +1,83 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDebugHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	queue := fill(4, 1, 2, 3)
+	tuned := ringbuffer.NewAutoTuned[int](1, ringbuffer.AutoTuning{Min: 1, Max: 1})
+	tuned.Push(1)
+	tuned.Push(2)
+	metered := ringbuffer.NewMetered[int](2, time.Second)
+	events := ringbuffer.NewBroadcast[int](2, ringbuffer.BlockSlowest)
+	events.SetClock(ringbuffer.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	events.SubscribeNamed("mailer")
+	events.Publish(1)
+	h := ringbuffer.DebugHandler(map[string]ringbuffer.Inspector{
+		"queue":   &queue,
+		"tuned":   &tuned,
+		"metered": &metered,
+		"events":  events,
+	})
+
+	get := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+
+	rec := get("/debug/ringbuffers?sample=2")
+	assert.Equal("application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(`{
+		"queue": {"len": 3, "cap": 4, "sample": [1, 2]},
+		"tuned": {"len": 1, "cap": 1, "drops": 1},
+		"metered": {"len": 0, "cap": 2, "rates": {"Push": 0, "Pop": 0}},
+		"events": {"len": 1, "cap": 2, "subscribers": [
+			{"name": "mailer", "lag": 1, "missed": 0, "last_read": "2024-01-01T00:00:00Z"}
+		]}
+	}`, rec.Body.String())
+
+	rec = get("/debug/ringbuffers")
+	assert.NotContains(rec.Body.String(), "sample")
+
+	rec = get("/debug/ringbuffers?format=html&sample=5")
+	assert.Equal("text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(rec.Body.String(), "<td>queue</td><td>3</td><td>4</td>")
+	assert.Contains(rec.Body.String(), "<td>1 2 3 </td>")
+	assert.Contains(rec.Body.String(), "<td>events / mailer</td><td>1</td>")
+}
+
+func TestRingBufferString(t *testing.T) {
+	assert := assert.New(t)
+
+	var empty ringbuffer.RingBuffer[int]
+	assert.Equal("RingBuffer[0/0]{}", empty.String())
+
+	b := ringbuffer.New[string](3)
+	b.Push("a")
+	b.Push("b")
+	assert.Equal("RingBuffer[2/3]{a, b}", b.String())
+	assert.Equal("queue: RingBuffer[2/3]{a, b}", fmt.Sprintf("queue: %v", b))
+
+	big := ringbuffer.New[int](100)
+	for i := 0; i < 20; i++ {
+		big.Push(i)
+	}
+	assert.Equal("RingBuffer[20/100]{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, ... 4 more}", big.String())
+}
+
+func ExampleRingBuffer_String() {
+	b := ringbuffer.New[int](8)
+	b.PushMany([]int{1, 2, 3})
+	fmt.Println(b)
+	// Output: RingBuffer[3/8]{1, 2, 3}
+}
@@ -0,0 +1,323 @@
+// Package provides a persistent circular log of variable length records in a fixed size file.
+//
+// Records are appended at the end of the log, when there is not enough space the oldest records are overwritten.
+// The file never grows past its initial size.
+//
+// The log is crash-safe. There are two copies of the header, written alternately and protected by a checksum, so that
+// a torn header write leaves the previous one intact. Every record carries a checksum of its payload and position,
+// so that a record left over from a previous lap at the same place in the file is not mistaken for a new one.
+// Eviction of the oldest records is committed to the header before their space is reused. On Open the records are
+// verified and the log is truncated at the first record which didn't make it to the file completely, see Discarded.
+// Use Sync to make appended records durable.
+//
+// Consumers can commit the offset they processed the log up to under a name, so that they resume there after a
+// restart with Committed and IterateFrom. Offsets are stored in a separate file next to the log, see Commit.
+//
+// File layout, all integers little endian. Two header slots at offsets 0 and 64, each:
+//
+//	offset  size  field
+//	0       4     magic "RBLG"
+//	4       4     format version
+//	8       8     size of the data region in bytes
+//	16      8     head, logical offset of the oldest record
+//	24      8     tail, logical offset past the newest record
+//	32      8     number of records
+//	40      8     header generation, the valid slot with the highest generation is current
+//	48      4     CRC-32 (IEEE) of the preceding 48 bytes
+//
+// The data region follows at offset 128. Logical offsets grow forever, the physical position in the data region is
+// the offset modulo its size. Every record is stored as a 4 byte payload length, a 4 byte CRC-32 (IEEE) of the 8 byte
+// logical offset of the record followed by the payload, and the payload. Any part of a record may wrap around the end
+// of the data region.
+package disklog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+)
+
+const (
+	magic      = "RBLG"
+	version    = 2
+	slotSize   = 64
+	headerSize = 2 * slotSize
+	frameSize  = 8
+
+	offVersion = 4
+	offSize    = 8
+	offHead    = 16
+	offTail    = 24
+	offCount   = 32
+	offGen     = 40
+	offCRC     = 48
+)
+
+var (
+	// Returned by Open for files which are not logs of the requested size.
+	ErrMismatch = errors.New("disklog: file does not match log geometry")
+	// Returned by Append for records which can't fit even into an empty log.
+	ErrTooLarge = errors.New("disklog: record larger than log")
+)
+
+// Persistent circular log stored in a file. Not safe for concurrent use.
+type Log struct {
+	f         *os.File
+	path      string
+	size      uint64
+	head      uint64
+	tail      uint64
+	count     int
+	gen       uint64
+	discarded int
+	offsets   map[string]uint64 // committed offsets by consumer name
+}
+
+// Open or create a log file with a data region of size bytes, recovering it after a crash if needed.
+//
+// A new or empty file is initialized. An existing log file must have the same size, otherwise ErrMismatch is
+// returned.
+func Open(path string, size int) (*Log, error) {
+	if size <= frameSize {
+		return nil, fmt.Errorf("disklog: invalid size %d", size)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	l := &Log{f: f, path: path, size: uint64(size)}
+	if err := l.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := l.loadOffsets(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *Log) load() error {
+	fi, err := l.f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.Size() == 0 {
+		if err := l.f.Truncate(int64(headerSize + l.size)); err != nil {
+			return err
+		}
+		return l.writeHeader()
+	}
+	if fi.Size() != int64(headerSize+l.size) {
+		return ErrMismatch
+	}
+	var hdr [headerSize]byte
+	if _, err := l.f.ReadAt(hdr[:], 0); err != nil {
+		return err
+	}
+	if string(hdr[:len(magic)]) != magic && string(hdr[slotSize:slotSize+len(magic)]) != magic {
+		return ErrMismatch
+	}
+	var cur []byte
+	for i := 0; i < 2; i++ {
+		h := hdr[i*slotSize : (i+1)*slotSize]
+		if string(h[:len(magic)]) != magic || crc32.ChecksumIEEE(h[:offCRC]) != binary.LittleEndian.Uint32(h[offCRC:]) {
+			continue
+		}
+		if v := binary.LittleEndian.Uint32(h[offVersion:]); v != version {
+			return fmt.Errorf("disklog: unsupported format version %d", v)
+		}
+		if cur == nil || binary.LittleEndian.Uint64(h[offGen:]) > binary.LittleEndian.Uint64(cur[offGen:]) {
+			cur = h
+		}
+	}
+	if cur == nil {
+		return errors.New("disklog: no intact header")
+	}
+	if binary.LittleEndian.Uint64(cur[offSize:]) != l.size {
+		return ErrMismatch
+	}
+	l.head = binary.LittleEndian.Uint64(cur[offHead:])
+	l.tail = binary.LittleEndian.Uint64(cur[offTail:])
+	l.gen = binary.LittleEndian.Uint64(cur[offGen:])
+	expected := int(binary.LittleEndian.Uint64(cur[offCount:]))
+	if l.tail < l.head || l.tail-l.head > l.size {
+		return fmt.Errorf("disklog: corrupt header, head %d tail %d", l.head, l.tail)
+	}
+	return l.recover(expected)
+}
+
+// Verify records from head to tail, truncating the log at the first damaged one.
+func (l *Log) recover(expected int) error {
+	pos := l.head
+	for pos < l.tail {
+		n, sum, err := l.frame(pos)
+		if err != nil {
+			return err
+		}
+		if pos+frameSize+n > l.tail {
+			break
+		}
+		rec := make([]byte, n)
+		if err := l.readAt(rec, pos+frameSize); err != nil {
+			return err
+		}
+		if recordSum(pos, rec) != sum {
+			break
+		}
+		pos += frameSize + n
+		l.count++
+	}
+	if pos == l.tail {
+		return nil
+	}
+	l.tail = pos
+	l.discarded = max(expected-l.count, 1)
+	return l.writeHeader()
+}
+
+// Write the current state to the older header slot.
+func (l *Log) writeHeader() error {
+	l.gen++
+	var hdr [slotSize]byte
+	copy(hdr[:], magic)
+	binary.LittleEndian.PutUint32(hdr[offVersion:], version)
+	binary.LittleEndian.PutUint64(hdr[offSize:], l.size)
+	binary.LittleEndian.PutUint64(hdr[offHead:], l.head)
+	binary.LittleEndian.PutUint64(hdr[offTail:], l.tail)
+	binary.LittleEndian.PutUint64(hdr[offCount:], uint64(l.count))
+	binary.LittleEndian.PutUint64(hdr[offGen:], l.gen)
+	binary.LittleEndian.PutUint32(hdr[offCRC:], crc32.ChecksumIEEE(hdr[:offCRC]))
+	_, err := l.f.WriteAt(hdr[:], int64(l.gen%2)*slotSize)
+	return err
+}
+
+// Read len(p) bytes of the data region at logical offset pos, wrapping around its end.
+func (l *Log) readAt(p []byte, pos uint64) error {
+	off := pos % l.size
+	n := min(uint64(len(p)), l.size-off)
+	if _, err := l.f.ReadAt(p[:n], int64(headerSize+off)); err != nil {
+		return err
+	}
+	if n < uint64(len(p)) {
+		if _, err := l.f.ReadAt(p[n:], headerSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write p to the data region at logical offset pos, wrapping around its end.
+func (l *Log) writeAt(p []byte, pos uint64) error {
+	off := pos % l.size
+	n := min(uint64(len(p)), l.size-off)
+	if _, err := l.f.WriteAt(p[:n], int64(headerSize+off)); err != nil {
+		return err
+	}
+	if n < uint64(len(p)) {
+		if _, err := l.f.WriteAt(p[n:], headerSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read the payload length and checksum of the record at logical offset pos.
+func (l *Log) frame(pos uint64) (uint64, uint32, error) {
+	var b [frameSize]byte
+	if err := l.readAt(b[:], pos); err != nil {
+		return 0, 0, err
+	}
+	return uint64(binary.LittleEndian.Uint32(b[:])), binary.LittleEndian.Uint32(b[4:]), nil
+}
+
+// Checksum of a record payload at logical offset pos.
+func recordSum(pos uint64, rec []byte) uint32 {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], pos)
+	return crc32.Update(crc32.ChecksumIEEE(b[:]), crc32.IEEETable, rec)
+}
+
+// How many records are currently stored?
+func (l *Log) Len() int {
+	return l.count
+}
+
+// How many records were lost to a crash and discarded when the log was opened? At least 1 if the log was truncated.
+func (l *Log) Discarded() int {
+	return l.discarded
+}
+
+// Append a record to the log, overwriting as many of the oldest records as needed to make space.
+//
+// Returns ErrTooLarge if the record can't fit even into an empty log.
+func (l *Log) Append(rec []byte) error {
+	need := uint64(frameSize + len(rec))
+	if need > l.size {
+		return ErrTooLarge
+	}
+	head := l.head
+	count := l.count
+	for l.size-(l.tail-head) < need {
+		n, _, err := l.frame(head)
+		if err != nil {
+			return err
+		}
+		head += frameSize + n
+		count--
+	}
+	if head != l.head {
+		// commit the eviction before overwriting the evicted records
+		l.head, l.count = head, count
+		if err := l.writeHeader(); err != nil {
+			return err
+		}
+	}
+	buf := make([]byte, need)
+	binary.LittleEndian.PutUint32(buf, uint32(len(rec)))
+	binary.LittleEndian.PutUint32(buf[4:], recordSum(l.tail, rec))
+	copy(buf[frameSize:], rec)
+	if err := l.writeAt(buf, l.tail); err != nil {
+		return err
+	}
+	l.tail += need
+	l.count++
+	return l.writeHeader()
+}
+
+// Call f for each record from the oldest to the newest, until f returns false.
+//
+// The slice passed to f is only valid during the call.
+func (l *Log) Iterate(f func(rec []byte) bool) error {
+	var buf []byte
+	for pos := l.head; pos < l.tail; {
+		n, _, err := l.frame(pos)
+		if err != nil {
+			return err
+		}
+		if uint64(cap(buf)) < n {
+			buf = make([]byte, n)
+		}
+		buf = buf[:n]
+		if err := l.readAt(buf, pos+frameSize); err != nil {
+			return err
+		}
+		if !f(buf) {
+			return nil
+		}
+		pos += frameSize + n
+	}
+	return nil
+}
+
+// Commit the file contents to stable storage.
+func (l *Log) Sync() error {
+	return l.f.Sync()
+}
+
+// Close the file. The log must not be used afterwards.
+func (l *Log) Close() error {
+	return l.f.Close()
+}
@@ -0,0 +1,174 @@
+package disklog_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer/disklog"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func records(l *disklog.Log) []string {
+	var out []string
+	l.Iterate(func(rec []byte) bool {
+		out = append(out, string(rec))
+		return true
+	})
+	return out
+}
+
+func TestLog(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "journal")
+
+	_, err := disklog.Open(path, 4)
+	assert.Error(err)
+
+	l, err := disklog.Open(path, 27)
+	assert.NoError(err)
+	assert.ErrorIs(l.Append(make([]byte, 20)), disklog.ErrTooLarge)
+	assert.NoError(l.Append([]byte("aaaa")))
+	assert.NoError(l.Append([]byte("bbbb")))
+	assert.Equal([]string{"aaaa", "bbbb"}, records(l))
+
+	// oldest records are overwritten, records wrap around the end of the file
+	assert.NoError(l.Append([]byte("cc")))
+	assert.Equal([]string{"bbbb", "cc"}, records(l))
+	assert.NoError(l.Append([]byte("ddddddd")))
+	assert.Equal([]string{"cc", "ddddddd"}, records(l))
+	assert.NoError(l.Append(nil))
+	assert.Equal([]string{"ddddddd", ""}, records(l))
+	assert.Equal(2, l.Len())
+	assert.NoError(l.Append(make([]byte, 16)))
+	assert.Equal(1, l.Len())
+	for i := 0; i < 50; i++ {
+		assert.NoError(l.Append([]byte(strings.Repeat("x", i%7))))
+	}
+	assert.NoError(l.Sync())
+
+	// early stop
+	var first []string
+	l.Iterate(func(rec []byte) bool {
+		first = append(first, string(rec))
+		return false
+	})
+	assert.Equal(1, len(first))
+
+	// contents survive reopening
+	before := records(l)
+	assert.NoError(l.Close())
+	l, err = disklog.Open(path, 27)
+	assert.NoError(err)
+	assert.Equal(before, records(l))
+	assert.Equal(0, l.Discarded())
+	assert.Equal(len(before), l.Len())
+	assert.NoError(l.Close())
+
+	_, err = disklog.Open(path, 40)
+	assert.ErrorIs(err, disklog.ErrMismatch)
+	other := filepath.Join(t.TempDir(), "other")
+	assert.NoError(os.WriteFile(other, make([]byte, 128+27), 0o644))
+	_, err = disklog.Open(other, 27)
+	assert.ErrorIs(err, disklog.ErrMismatch)
+}
+
+func TestRecovery(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "journal")
+
+	l, err := disklog.Open(path, 64)
+	assert.NoError(err)
+	for _, rec := range []string{"one", "two", "three"} {
+		assert.NoError(l.Append([]byte(rec)))
+	}
+	assert.NoError(l.Close())
+
+	// torn write of the newest record: its payload starts after the headers and two 11 byte records
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	assert.NoError(err)
+	_, err = f.WriteAt([]byte{'X'}, 128+11+11+8)
+	assert.NoError(err)
+	assert.NoError(f.Close())
+	l, err = disklog.Open(path, 64)
+	assert.NoError(err)
+	assert.Equal(1, l.Discarded())
+	assert.Equal(2, l.Len())
+	assert.Equal([]string{"one", "two"}, records(l))
+	assert.NoError(l.Append([]byte("four")))
+	assert.NoError(l.Close())
+
+	// the repair is persistent
+	l, err = disklog.Open(path, 64)
+	assert.NoError(err)
+	assert.Equal(0, l.Discarded())
+	assert.Equal([]string{"one", "two", "four"}, records(l))
+	assert.NoError(l.Close())
+
+	// a torn header write falls back to the other copy, which doesn't include the last append yet
+	data, err := os.ReadFile(path)
+	assert.NoError(err)
+	cur := 0
+	if data[64+40] > data[40] {
+		cur = 64
+	}
+	data[cur+20] ^= 0xff
+	assert.NoError(os.WriteFile(path, data, 0o644))
+	l, err = disklog.Open(path, 64)
+	assert.NoError(err)
+	assert.Equal([]string{"one", "two"}, records(l))
+	assert.NoError(l.Close())
+
+	// both copies damaged
+	data, err = os.ReadFile(path)
+	assert.NoError(err)
+	data[30] ^= 0xff
+	data[64+30] ^= 0xff
+	assert.NoError(os.WriteFile(path, data, 0o644))
+	_, err = disklog.Open(path, 64)
+	assert.Error(err)
+}
+
+func TestRecoveryPreviousLap(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "journal")
+
+	l, err := disklog.Open(path, 24)
+	assert.NoError(err)
+	assert.NoError(l.Append([]byte("aaaa")))
+	assert.NoError(l.Append([]byte("bbbb")))
+	old, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.NoError(l.Append([]byte("cccc")))
+	assert.NoError(l.Close())
+
+	// the header of the last append reached the disk, its data didn't and the evicted record is still in place
+	data, err := os.ReadFile(path)
+	assert.NoError(err)
+	copy(data[128:128+12], old[128:128+12])
+	assert.NoError(os.WriteFile(path, data, 0o644))
+	l, err = disklog.Open(path, 24)
+	assert.NoError(err)
+	assert.Equal(1, l.Discarded())
+	assert.Equal([]string{"bbbb"}, records(l))
+	assert.NoError(l.Close())
+}
+
+func Example() {
+	dir, _ := os.MkdirTemp("", "disklog")
+	defer os.RemoveAll(dir)
+
+	l, _ := disklog.Open(filepath.Join(dir, "journal"), 32)
+	defer l.Close()
+	for _, ev := range []string{"boot", "config loaded", "listening", "request"} {
+		l.Append([]byte(ev))
+	}
+	l.Iterate(func(rec []byte) bool {
+		fmt.Println(string(rec))
+		return true
+	})
+	// Output:
+	// listening
+	// request
+}
@@ -0,0 +1,189 @@
+package disklog
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"maps"
+	"os"
+	"slices"
+)
+
+// Committed consumer offsets are kept in a separate file next to the log, named after it with an ".offsets" suffix.
+// It is replaced as a whole on every commit, by writing a temporary file and renaming it over the old one. Layout,
+// all integers little endian:
+//
+//	offset  size  field
+//	0       4     magic "RBOF"
+//	4       4     format version
+//	8       4     number of consumers
+//	12      ...   per consumer: 2 byte name length, name, 8 byte committed logical offset
+//	...     4     CRC-32 (IEEE) of all preceding bytes
+const (
+	offsetsMagic   = "RBOF"
+	offsetsVersion = 1
+	offsetsSuffix  = ".offsets"
+)
+
+// Returned for consumer offsets which point neither at a stored record nor at the end of the log.
+var ErrOffset = errors.New("disklog: offset is not a record boundary")
+
+func (l *Log) loadOffsets() error {
+	data, err := os.ReadFile(l.path + offsetsSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	corrupt := fmt.Errorf("disklog: corrupt offsets file %s", l.path+offsetsSuffix)
+	if len(data) < 16 || string(data[:len(offsetsMagic)]) != offsetsMagic {
+		return corrupt
+	}
+	body, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != sum {
+		return corrupt
+	}
+	if v := binary.LittleEndian.Uint32(body[4:]); v != offsetsVersion {
+		return fmt.Errorf("disklog: unsupported offsets format version %d", v)
+	}
+	n := binary.LittleEndian.Uint32(body[8:])
+	body = body[12:]
+	offsets := make(map[string]uint64, n)
+	for range n {
+		if len(body) < 2 {
+			return corrupt
+		}
+		nameLen := int(binary.LittleEndian.Uint16(body))
+		if len(body) < 2+nameLen+8 {
+			return corrupt
+		}
+		offsets[string(body[2:2+nameLen])] = binary.LittleEndian.Uint64(body[2+nameLen:])
+		body = body[2+nameLen+8:]
+	}
+	if len(body) != 0 {
+		return corrupt
+	}
+	l.offsets = offsets
+	// records past the end of the log were lost to a crash, their offsets will be reused by new records
+	clamped := false
+	for name, off := range offsets {
+		if off > l.tail {
+			offsets[name] = l.tail
+			clamped = true
+		}
+	}
+	if clamped {
+		return l.writeOffsets()
+	}
+	return nil
+}
+
+func (l *Log) writeOffsets() error {
+	buf := []byte(offsetsMagic)
+	buf = binary.LittleEndian.AppendUint32(buf, offsetsVersion)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(l.offsets)))
+	for _, name := range slices.Sorted(maps.Keys(l.offsets)) {
+		buf = binary.LittleEndian.AppendUint16(buf, uint16(len(name)))
+		buf = append(buf, name...)
+		buf = binary.LittleEndian.AppendUint64(buf, l.offsets[name])
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+
+	tmp := l.path + offsetsSuffix + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, l.path+offsetsSuffix)
+}
+
+// What is the offset consumer name resumes from?
+//
+// Offsets are logical positions of records in the log, as passed to IterateFrom. A consumer which never committed
+// starts at the oldest record, and so does one whose records were overwritten meanwhile. An offset past records lost
+// to a crash is moved back to the end of the log when it is opened.
+func (l *Log) Committed(name string) uint64 {
+	return max(l.offsets[name], l.head)
+}
+
+// Persist the offset consumer name resumes from, typically the one passed to IterateFrom with the last processed
+// record.
+//
+// Every commit replaces the offsets file and syncs it, commit after batches of records rather than after each one if
+// that is too slow. Returns ErrOffset if offset is outside the log.
+func (l *Log) Commit(name string, offset uint64) error {
+	if len(name) > 0xffff {
+		return errors.New("disklog: consumer name too long")
+	}
+	if offset < l.head || offset > l.tail {
+		return ErrOffset
+	}
+	if l.offsets == nil {
+		l.offsets = make(map[string]uint64)
+	}
+	l.offsets[name] = offset
+	return l.writeOffsets()
+}
+
+// Forget the committed offset of consumer name.
+func (l *Log) Uncommit(name string) error {
+	if _, ok := l.offsets[name]; !ok {
+		return nil
+	}
+	delete(l.offsets, name)
+	return l.writeOffsets()
+}
+
+// Get names of all consumers with a committed offset in sorted order.
+func (l *Log) Consumers() []string {
+	return slices.Sorted(maps.Keys(l.offsets))
+}
+
+// Call f for each record from offset to the newest, until f returns false. See Committed.
+//
+// Next is the offset of the record after rec, to commit once rec is processed. Offsets of overwritten records start
+// at the oldest record. Returns ErrOffset if offset doesn't point at a record or the end of the log. The slice passed
+// to f is only valid during the call.
+func (l *Log) IterateFrom(offset uint64, f func(next uint64, rec []byte) bool) error {
+	pos := max(offset, l.head)
+	if pos > l.tail {
+		return ErrOffset
+	}
+	var buf []byte
+	for first := true; pos < l.tail; first = false {
+		n, sum, err := l.frame(pos)
+		if err != nil {
+			return err
+		}
+		if pos+frameSize+n > l.tail {
+			return ErrOffset
+		}
+		if uint64(cap(buf)) < n {
+			buf = make([]byte, n)
+		}
+		buf = buf[:n]
+		if err := l.readAt(buf, pos+frameSize); err != nil {
+			return err
+		}
+		if first && recordSum(pos, buf) != sum {
+			return ErrOffset
+		}
+		pos += frameSize + n
+		if !f(pos, buf) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package disklog_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer/disklog"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Process records of consumer name from its committed offset on, committing after each one.
+func consume(l *disklog.Log, name string, max int) []string {
+	var out []string
+	l.IterateFrom(l.Committed(name), func(next uint64, rec []byte) bool {
+		if len(out) == max {
+			return false
+		}
+		out = append(out, string(rec))
+		l.Commit(name, next)
+		return true
+	})
+	return out
+}
+
+func TestOffsets(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "journal")
+
+	l, err := disklog.Open(path, 64)
+	assert.NoError(err)
+	for _, rec := range []string{"one", "two", "three"} {
+		assert.NoError(l.Append([]byte(rec)))
+	}
+	assert.Equal(uint64(0), l.Committed("mailer"))
+	assert.Equal([]string{"one", "two"}, consume(l, "mailer", 2))
+	assert.Equal([]string{"one"}, consume(l, "audit", 1))
+	assert.Equal([]string{"audit", "mailer"}, l.Consumers())
+	assert.NoError(l.Close())
+
+	// consumers resume where they left off after reopening
+	l, err = disklog.Open(path, 64)
+	assert.NoError(err)
+	assert.Equal(uint64(22), l.Committed("mailer"))
+	assert.Equal([]string{"three"}, consume(l, "mailer", 10))
+	assert.Equal([]string(nil), consume(l, "mailer", 10))
+
+	// offsets must point at records
+	assert.ErrorIs(l.Commit("mailer", 1000), disklog.ErrOffset)
+	assert.ErrorIs(l.IterateFrom(1000, func(uint64, []byte) bool { return true }), disklog.ErrOffset)
+	assert.ErrorIs(l.IterateFrom(3, func(uint64, []byte) bool { return true }), disklog.ErrOffset)
+
+	// overwritten records are skipped
+	for _, rec := range []string{"four", "five", "six", "seven"} {
+		assert.NoError(l.Append([]byte(rec)))
+	}
+	assert.Equal([]string{"three", "four", "five", "six", "seven"}, records(l))
+	assert.Equal([]string{"three", "four", "five", "six", "seven"}, consume(l, "audit", 10))
+	assert.Equal([]string{"four", "five", "six", "seven"}, consume(l, "mailer", 10))
+	assert.Equal([]string{"three"}, consume(l, "late", 1))
+
+	assert.NoError(l.Uncommit("late"))
+	assert.NoError(l.Uncommit("late"))
+	assert.Equal([]string{"audit", "mailer"}, l.Consumers())
+	assert.NoError(l.Close())
+
+	// a damaged offsets file is reported
+	data, err := os.ReadFile(path + ".offsets")
+	assert.NoError(err)
+	data[len(data)-6] ^= 0xff
+	assert.NoError(os.WriteFile(path+".offsets", data, 0o644))
+	_, err = disklog.Open(path, 64)
+	assert.Error(err)
+}
+
+func TestOffsetsRecovery(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "journal")
+
+	l, err := disklog.Open(path, 64)
+	assert.NoError(err)
+	for _, rec := range []string{"one", "two", "three"} {
+		assert.NoError(l.Append([]byte(rec)))
+	}
+	assert.Equal(3, len(consume(l, "mailer", 10)))
+	assert.NoError(l.Close())
+
+	// the newest record is lost to a crash, the consumer continues with the records appended next
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	assert.NoError(err)
+	_, err = f.WriteAt([]byte{'X'}, 128+11+11+8)
+	assert.NoError(err)
+	assert.NoError(f.Close())
+	l, err = disklog.Open(path, 64)
+	assert.NoError(err)
+	assert.Equal(uint64(22), l.Committed("mailer"))
+	assert.NoError(l.Append([]byte("four")))
+	assert.Equal([]string{"four"}, consume(l, "mailer", 10))
+	assert.NoError(l.Close())
+}
+
+func ExampleLog_IterateFrom() {
+	dir, _ := os.MkdirTemp("", "disklog")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "journal")
+
+	l, _ := disklog.Open(path, 64)
+	for _, ev := range []string{"boot", "config loaded", "listening"} {
+		l.Append([]byte(ev))
+	}
+	l.IterateFrom(l.Committed("indexer"), func(next uint64, rec []byte) bool {
+		fmt.Println(string(rec))
+		l.Commit("indexer", next)
+		return string(rec) != "config loaded" // crash here
+	})
+	l.Close()
+
+	// restarted
+	l, _ = disklog.Open(path, 64)
+	defer l.Close()
+	l.IterateFrom(l.Committed("indexer"), func(next uint64, rec []byte) bool {
+		fmt.Println("resumed:", string(rec))
+		l.Commit("indexer", next)
+		return true
+	})
+	// Output:
+	// boot
+	// config loaded
+	// resumed: listening
+}
@@ -0,0 +1,65 @@
+package ringbuffer
+
+// Round-robin consumer of several ring buffers.
+//
+// Each turn pops up to weight elements from one buffer before moving to the next one, so that a busy buffer cannot
+// starve the others. Every buffer has a weight of 1 unless weights are given, which makes it plain round-robin. The
+// position in the rotation is remembered between Drain calls.
+type FairDrainer[T any] struct {
+	rings   []*RingBuffer[T]
+	weights []int
+	next    int
+	credit  int
+}
+
+// Create a new drainer over the given buffers.
+func NewFairDrainer[T any](rings ...*RingBuffer[T]) FairDrainer[T] {
+	return FairDrainer[T]{rings: rings}
+}
+
+// Create a new drainer over the given buffers, the i-th buffer gets up to weights[i] pops per turn.
+//
+// For example, weights 4, 2 and 1 over three priority lanes keep low priority lanes progressing under sustained high
+// priority load. Missing or non-positive weights are treated as 1.
+func NewWeightedDrainer[T any](weights []int, rings ...*RingBuffer[T]) FairDrainer[T] {
+	return FairDrainer[T]{rings: rings, weights: weights}
+}
+
+func (d *FairDrainer[T]) weight(i int) int {
+	if i < len(d.weights) && d.weights[i] > 0 {
+		return d.weights[i]
+	}
+	return 1
+}
+
+func (d *FairDrainer[T]) advance() {
+	d.next = (d.next + 1) % len(d.rings)
+	d.credit = 0
+}
+
+// Pop up to max elements in round-robin order and pass each of them to f along with the index of its buffer.
+//
+// Returns how many elements were popped. Fewer than max elements are popped only when all buffers are empty.
+func (d *FairDrainer[T]) Drain(max int, f func(ring int, v T)) int {
+	n := 0
+	idle := 0
+	for n < max && idle < len(d.rings) {
+		if d.credit == 0 {
+			d.credit = d.weight(d.next)
+		}
+		i := d.next
+		v, ok := d.rings[i].Pop()
+		if !ok {
+			d.advance()
+			idle++
+			continue
+		}
+		idle = 0
+		f(i, v)
+		n++
+		if d.credit--; d.credit == 0 {
+			d.advance()
+		}
+	}
+	return n
+}
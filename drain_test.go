@@ -0,0 +1,79 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFairDrainer(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var d ringbuffer.FairDrainer[int]
+		assert.Equal(0, d.Drain(10, func(int, int) { t.Fail() }))
+	}
+
+	a := fill(10, 1, 2, 3, 4, 5)
+	b := fill(10, 10)
+	c := fill(10, 100, 200)
+	d := ringbuffer.NewFairDrainer(&a, &b, &c)
+
+	var got []int
+	collect := func(ring int, v int) { got = append(got, v) }
+	assert.Equal(2, d.Drain(2, collect))
+	assert.Equal([]int{1, 10}, got)
+
+	// rotation continues where the previous call stopped
+	assert.Equal(3, d.Drain(3, collect))
+	assert.Equal([]int{1, 10, 100, 2, 200}, got)
+
+	assert.Equal(3, d.Drain(10, collect))
+	assert.Equal([]int{1, 10, 100, 2, 200, 3, 4, 5}, got)
+	assert.Equal(0, d.Drain(10, collect))
+
+	b.Push(20)
+	assert.Equal(1, d.Drain(10, collect))
+	assert.Equal(20, got[len(got)-1])
+}
+
+func TestWeightedDrainer(t *testing.T) {
+	assert := assert.New(t)
+
+	high := ringbuffer.New[string](100)
+	mid := ringbuffer.New[string](100)
+	low := ringbuffer.New[string](100)
+	for i := 0; i < 20; i++ {
+		high.Push("h")
+		mid.Push("m")
+		low.Push("l")
+	}
+	d := ringbuffer.NewWeightedDrainer([]int{4, 2, 1}, &high, &mid, &low)
+	var got string
+	collect := func(ring int, v string) { got += v }
+	d.Drain(10, collect)
+	assert.Equal("hhhhmmlhhh", got)
+	d.Drain(4, collect)
+	assert.Equal("hhhhmmlhhhhmml", got)
+
+	// empty lanes give their share away
+	d2 := ringbuffer.NewWeightedDrainer([]int{2, 0}, &high, &low)
+	got = ""
+	for low.Len() > 0 {
+		low.Pop()
+	}
+	d2.Drain(3, collect)
+	assert.Equal("hhh", got)
+}
+
+func ExampleFairDrainer() {
+	tenantA := fill(5, "a1", "a2", "a3")
+	tenantB := fill(5, "b1")
+	d := ringbuffer.NewFairDrainer(&tenantA, &tenantB)
+	d.Drain(10, func(ring int, v string) {
+		fmt.Print(v, " ")
+	})
+	fmt.Println()
+	// Output: a1 b1 a2 a3
+}
@@ -0,0 +1,59 @@
+package ringbuffer
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Combiner which moves elements from several source buffers into one destination buffer in a background goroutine.
+//
+// Elements of each source reach the destination in the order they were pushed to it. Elements which don't fit into
+// the destination are dropped and counted per source, so sources never block on a slow destination.
+type FanIn[T any] struct {
+	cancel    context.CancelFunc
+	done      chan struct{}
+	forwarded []atomic.Uint64
+	dropped   []atomic.Uint64
+}
+
+// Start moving elements from srcs to dst. Call Stop to end the background goroutine.
+func StartFanIn[T any](dst *Sync[T], srcs ...*Sync[T]) *FanIn[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &FanIn[T]{
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		forwarded: make([]atomic.Uint64, len(srcs)),
+		dropped:   make([]atomic.Uint64, len(srcs)),
+	}
+	go func() {
+		defer close(f.done)
+		for {
+			i, v, err := Select(ctx, srcs...)
+			if err != nil {
+				return
+			}
+			if dst.Push(v) {
+				f.forwarded[i].Add(1)
+			} else {
+				f.dropped[i].Add(1)
+			}
+		}
+	}()
+	return f
+}
+
+// How many elements of the i-th source were moved to the destination so far?
+func (f *FanIn[T]) Forwarded(i int) uint64 {
+	return f.forwarded[i].Load()
+}
+
+// How many elements of the i-th source were dropped because the destination was full so far?
+func (f *FanIn[T]) Dropped(i int) uint64 {
+	return f.dropped[i].Load()
+}
+
+// Stop moving elements and wait for the background goroutine to exit. Elements still in sources stay there.
+func (f *FanIn[T]) Stop() {
+	f.cancel()
+	<-f.done
+}
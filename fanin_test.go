@@ -0,0 +1,86 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFanIn(t *testing.T) {
+	assert := assert.New(t)
+
+	const n = 500
+	srcs := []*ringbuffer.Sync[int]{ringbuffer.NewSync[int](4), ringbuffer.NewSync[int](4)}
+	dst := ringbuffer.NewSync[int](2 * n)
+	f := ringbuffer.StartFanIn(dst, srcs...)
+
+	var wg sync.WaitGroup
+	for s, src := range srcs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= n; {
+				if src.Push(s*n + i) {
+					i++
+				} else {
+					time.Sleep(time.Microsecond)
+				}
+			}
+		}()
+	}
+
+	// per-source FIFO order at the destination
+	last := []int{0, n}
+	for got := 0; got < 2*n; {
+		v, ok := dst.Pop()
+		if !ok {
+			time.Sleep(time.Microsecond)
+			continue
+		}
+		s := (v - 1) / n
+		if v != last[s]+1 {
+			assert.Equal(last[s]+1, v)
+			break
+		}
+		last[s] = v
+		got++
+	}
+	wg.Wait()
+	assert.Equal(uint64(n), f.Forwarded(0))
+	assert.Equal(uint64(n), f.Forwarded(1))
+	assert.Equal(uint64(0), f.Dropped(0))
+
+	// a full destination drops elements, counted per source
+	for dst.Push(0) {
+	}
+	srcs[1].Push(-1)
+	for f.Dropped(1) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	f.Stop()
+	assert.Equal(uint64(0), f.Dropped(0))
+	assert.Equal(uint64(1), f.Dropped(1))
+
+	// stopped, elements stay in sources
+	srcs[0].Push(1)
+	time.Sleep(time.Millisecond)
+	assert.Equal(1, srcs[0].Len())
+}
+
+func ExampleFanIn() {
+	web := ringbuffer.NewSync[string](8)
+	cli := ringbuffer.NewSync[string](8)
+	all := ringbuffer.NewSync[string](8)
+	f := ringbuffer.StartFanIn(all, web, cli)
+	web.Push("GET /")
+	web.Push("GET /about")
+	for all.Len() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	f.Stop()
+	fmt.Println(all.Drain(), f.Forwarded(0), f.Dropped(0))
+	// Output: [GET / GET /about] 2 0
+}
@@ -0,0 +1,68 @@
+package ringbuffer
+
+type fanOutMode int
+
+const (
+	fanOutRoundRobin fanOutMode = iota
+	fanOutHashed
+	fanOutLeastLoaded
+)
+
+// Splitter which distributes pushed elements across several destination buffers.
+type FanOut[T any] struct {
+	rings []*RingBuffer[T]
+	mode  fanOutMode
+	hash  func(T) uint64
+	next  int
+}
+
+// Create a splitter which pushes to destinations in turn. Full destinations are skipped.
+func NewRoundRobinFanOut[T any](rings ...*RingBuffer[T]) FanOut[T] {
+	return FanOut[T]{rings: rings, mode: fanOutRoundRobin}
+}
+
+// Create a splitter which picks the destination by hash of the element, so that equal keys always end up in the same
+// buffer. Pushing fails if that buffer is full.
+func NewHashedFanOut[T any](hash func(T) uint64, rings ...*RingBuffer[T]) FanOut[T] {
+	return FanOut[T]{rings: rings, mode: fanOutHashed, hash: hash}
+}
+
+// Create a splitter which pushes to the destination storing the fewest elements, the first one on ties. Full
+// destinations are skipped.
+func NewLeastLoadedFanOut[T any](rings ...*RingBuffer[T]) FanOut[T] {
+	return FanOut[T]{rings: rings, mode: fanOutLeastLoaded}
+}
+
+// Push a new element to one of the destinations.
+//
+// Returns the index of the destination and true on success. Returns false if no suitable destination had free space.
+func (f *FanOut[T]) Push(v T) (int, bool) {
+	if len(f.rings) == 0 {
+		return 0, false
+	}
+	switch f.mode {
+	case fanOutHashed:
+		i := int(f.hash(v) % uint64(len(f.rings)))
+		return i, f.rings[i].Push(v)
+	case fanOutLeastLoaded:
+		best := -1
+		for i, r := range f.rings {
+			if r.Len() < r.Cap() && (best < 0 || r.Len() < f.rings[best].Len()) {
+				best = i
+			}
+		}
+		if best < 0 {
+			return 0, false
+		}
+		return best, f.rings[best].Push(v)
+	default:
+		for range f.rings {
+			i := f.next
+			f.next = (f.next + 1) % len(f.rings)
+			if f.rings[i].Push(v) {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+}
@@ -0,0 +1,71 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFanOut(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var f ringbuffer.FanOut[int]
+		_, ok := f.Push(1)
+		assert.Equal(false, ok)
+	}
+
+	{
+		a, b := ringbuffer.New[int](1), ringbuffer.New[int](3)
+		f := ringbuffer.NewRoundRobinFanOut(&a, &b)
+		var picks []int
+		for i := 0; i < 5; i++ {
+			idx, ok := f.Push(i)
+			if ok {
+				picks = append(picks, idx)
+			}
+		}
+		assert.Equal([]int{0, 1, 1, 1}, picks)
+		assert.Equal([]int{0}, contents(&a))
+		assert.Equal([]int{1, 2, 3}, contents(&b))
+	}
+
+	{
+		a, b := ringbuffer.New[int](2), ringbuffer.New[int](2)
+		f := ringbuffer.NewHashedFanOut(func(v int) uint64 { return uint64(v) }, &a, &b)
+		for _, v := range []int{1, 2, 3, 5} {
+			f.Push(v)
+		}
+		assert.Equal([]int{2}, contents(&a))
+		assert.Equal([]int{1, 3}, contents(&b))
+		idx, ok := f.Push(7)
+		assert.Equal(1, idx)
+		assert.Equal(false, ok)
+	}
+
+	{
+		a, b := ringbuffer.New[int](3), ringbuffer.New[int](1)
+		a.Push(0)
+		f := ringbuffer.NewLeastLoadedFanOut(&a, &b)
+		var picks []int
+		for i := 1; i <= 4; i++ {
+			idx, ok := f.Push(i)
+			if ok {
+				picks = append(picks, idx)
+			}
+		}
+		assert.Equal([]int{1, 0, 0}, picks)
+	}
+}
+
+func ExampleNewHashedFanOut() {
+	w0, w1 := ringbuffer.New[string](10), ringbuffer.New[string](10)
+	f := ringbuffer.NewHashedFanOut(func(s string) uint64 { return uint64(len(s)) }, &w0, &w1)
+	for _, s := range []string{"ab", "abc", "cd"} {
+		idx, _ := f.Push(s)
+		fmt.Print(idx, " ")
+	}
+	fmt.Println()
+	// Output: 0 1 0
+}
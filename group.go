@@ -0,0 +1,114 @@
+package ringbuffer
+
+import (
+	"maps"
+	"slices"
+)
+
+// Named consumer group of a Broadcast, remembering the offset up to which its consumers processed elements.
+//
+// Offsets count elements published to the ring, the first one has offset 0. Consumers of a group read through a
+// Subscriber created by Subscribe and commit the offset of the next element to process once they are done with the
+// previous ones, so that a new subscriber of the group resumes where the last one left off. With BlockSlowest, the
+// committed offset holds back publishing like a subscriber would, until the group is removed.
+type Group[T any] struct {
+	b         *Broadcast[T]
+	name      string
+	committed uint64
+}
+
+// Get the consumer group with the given name, creating it if needed. A new group starts at the next published element.
+func (b *Broadcast[T]) Group(name string) *Group[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if g, ok := b.groups[name]; ok {
+		return g
+	}
+	if b.groups == nil {
+		b.groups = make(map[string]*Group[T])
+	}
+	g := &Group[T]{b: b, name: name, committed: b.write}
+	b.groups[name] = g
+	return g
+}
+
+// Get names of all consumer groups in sorted order.
+func (b *Broadcast[T]) Groups() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Sorted(maps.Keys(b.groups))
+}
+
+// Get the lag of every consumer group by name, see Group.Lag.
+func (b *Broadcast[T]) Lags() map[string]uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lags := make(map[string]uint64, len(b.groups))
+	for name, g := range b.groups {
+		lags[name] = b.write - g.committed
+	}
+	return lags
+}
+
+// What is the name of the group?
+func (g *Group[T]) Name() string {
+	return g.name
+}
+
+// What is the offset of the next element the group has to process?
+func (g *Group[T]) Committed() uint64 {
+	g.b.mu.Lock()
+	defer g.b.mu.Unlock()
+	return g.committed
+}
+
+// How many published elements the group has not committed yet? With LapSlowest it includes elements which were lost.
+func (g *Group[T]) Lag() uint64 {
+	g.b.mu.Lock()
+	defer g.b.mu.Unlock()
+	return g.b.write - g.committed
+}
+
+// Mark elements before offset as processed, typically Subscriber.Offset after processing the element read last.
+//
+// Returns true on success. Returns false if offset is behind the committed offset, lies beyond the published
+// elements, or the group was removed.
+func (g *Group[T]) Commit(offset uint64) bool {
+	b := g.b
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.groups[g.name] != g || offset < g.committed || offset > b.write {
+		return false
+	}
+	g.committed = offset
+	b.cond.Broadcast()
+	return true
+}
+
+// Add a subscriber named after the group, which reads elements from the committed offset on. Elements which were lost
+// with LapSlowest are skipped and counted as missed.
+func (g *Group[T]) Subscribe() *Subscriber[T] {
+	b := g.b
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribe(g.name, g.committed)
+}
+
+// Remove the group, so that it no longer holds back publishing. Subscribers of the group are not affected.
+func (g *Group[T]) Remove() {
+	b := g.b
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.groups[g.name] == g {
+		delete(b.groups, g.name)
+		b.cond.Broadcast()
+	}
+}
+
+// What is the offset of the next element this subscriber reads?
+func (s *Subscriber[T]) Offset() uint64 {
+	s.b.mu.Lock()
+	defer s.b.mu.Unlock()
+	s.catchUp()
+	return s.read
+}
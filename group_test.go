@@ -0,0 +1,105 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBroadcast[int](4, ringbuffer.BlockSlowest)
+	b.TryPublish(0) // before the groups exist
+	billing := b.Group("billing")
+	audit := b.Group("audit")
+	assert.Equal(billing, b.Group("billing"))
+	assert.Equal("billing", billing.Name())
+	assert.Equal([]string{"audit", "billing"}, b.Groups())
+	assert.Equal(uint64(1), billing.Committed())
+
+	for i := 1; i <= 4; i++ {
+		assert.Equal(true, b.TryPublish(i))
+	}
+	// uncommitted elements hold back publishing
+	assert.Equal(false, b.TryPublish(5))
+	assert.Equal(map[string]uint64{"audit": 4, "billing": 4}, b.Lags())
+
+	// consume and commit, a new subscriber resumes after the committed elements
+	s := billing.Subscribe()
+	v, _ := s.Next()
+	assert.Equal(1, v)
+	v, _ = s.Next()
+	assert.Equal(2, v)
+	assert.Equal(true, billing.Commit(s.Offset()))
+	s.Unsubscribe()
+	assert.Equal(uint64(2), billing.Lag())
+	s = billing.Subscribe()
+	v, _ = s.Next()
+	assert.Equal(3, v)
+	s.Unsubscribe()
+	assert.Equal(uint64(3), billing.Committed())
+
+	// commits only move forward, within the published elements
+	assert.Equal(false, billing.Commit(2))
+	assert.Equal(false, billing.Commit(6))
+	assert.Equal(true, billing.Commit(5))
+	assert.Equal(uint64(0), billing.Lag())
+
+	// the lagging group still holds back publishing until removed
+	assert.Equal(false, b.TryPublish(5))
+	audit.Remove()
+	audit.Remove()
+	assert.Equal(false, audit.Commit(5))
+	assert.Equal([]string{"billing"}, b.Groups())
+	assert.Equal(true, b.TryPublish(5))
+
+	// recreated groups start over
+	fresh := b.Group("audit")
+	assert.NotSame(audit, fresh)
+	assert.Equal(uint64(6), fresh.Committed())
+	assert.Equal(uint64(0), fresh.Lag())
+}
+
+func TestGroupLapped(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBroadcast[int](2, ringbuffer.LapSlowest)
+	g := b.Group("slow")
+	for i := 0; i < 5; i++ {
+		b.Publish(i)
+	}
+	assert.Equal(uint64(5), g.Lag())
+
+	// lost elements are skipped and counted
+	s := g.Subscribe()
+	assert.Equal(uint64(3), s.Offset())
+	assert.Equal(uint64(3), s.Missed())
+	v, _ := s.Next()
+	assert.Equal(3, v)
+	assert.Equal(true, g.Commit(s.Offset()))
+	assert.Equal(uint64(1), g.Lag())
+}
+
+func ExampleGroup() {
+	log := ringbuffer.NewBroadcast[string](8, ringbuffer.BlockSlowest)
+	g := log.Group("mailer")
+	log.Publish("signup alice")
+	log.Publish("signup bob")
+
+	// first consumer processes one element and goes away
+	c := g.Subscribe()
+	v, _ := c.Next()
+	fmt.Println(v)
+	g.Commit(c.Offset())
+	c.Unsubscribe()
+
+	// the next one resumes after it
+	c = g.Subscribe()
+	v, _ = c.Next()
+	fmt.Println(v, g.Lag())
+	// Output:
+	// signup alice
+	// signup bob 1
+}
@@ -0,0 +1,85 @@
+package ringbuffer
+
+import (
+	"math"
+	"sort"
+)
+
+// Histogram with fixed buckets over the last N samples, for reporting rolling percentiles such as p50/p95/p99 latency.
+//
+// The ring stores the bucket of each sample in the window, bucket counts are updated on every push and eviction.
+// Bucket i counts samples in (bounds[i-1], bounds[i]], the first bucket starts at 0 (or includes everything up to
+// bounds[0] if it's not positive), and an extra last bucket counts samples above all bounds.
+type Histogram struct {
+	buf    RingBuffer[int]
+	bounds []float64
+	counts []int
+}
+
+// Create a new histogram over the last n samples, with buckets given by ascending upper bounds.
+func NewHistogram(n int, bounds []float64) Histogram {
+	return Histogram{
+		buf:    New[int](n),
+		bounds: append([]float64(nil), bounds...),
+		counts: make([]int, len(bounds)+1),
+	}
+}
+
+// How many samples a histogram covers?
+func (h Histogram) Cap() int {
+	return h.buf.Cap()
+}
+
+// How many samples are currently in the window?
+func (h Histogram) Len() int {
+	return h.buf.Len()
+}
+
+// Add a sample, evicting the oldest one if the window is full. Does nothing for zero length windows.
+func (h *Histogram) Push(v float64) {
+	if h.buf.Cap() == 0 {
+		return
+	}
+	if h.buf.IsFull() {
+		old, _ := h.buf.Pop()
+		h.counts[old]--
+	}
+	i := sort.SearchFloat64s(h.bounds, v)
+	h.buf.Push(i)
+	h.counts[i]++
+}
+
+// Get the number of samples in each bucket, the last element counting samples above all bounds.
+func (h Histogram) Counts() []int {
+	return append([]int(nil), h.counts...)
+}
+
+// Estimate the p-quantile of the samples in the window, 0 <= p <= 1, by linear interpolation within the bucket it
+// falls into. Quantiles in the last bucket are reported as the highest bound.
+//
+// Returns NaN if the window is empty, there are no bounds or p is out of range.
+func (h Histogram) Quantile(p float64) float64 {
+	n := h.buf.Len()
+	if n == 0 || len(h.bounds) == 0 || !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	rank := p * float64(n)
+	cum := 0
+	for i, c := range h.counts {
+		if c == 0 || float64(cum+c) < rank {
+			cum += c
+			continue
+		}
+		if i == len(h.bounds) {
+			return h.bounds[i-1]
+		}
+		lo := 0.0
+		if i > 0 {
+			lo = h.bounds[i-1]
+		} else if h.bounds[0] <= 0 {
+			return h.bounds[0]
+		}
+		return lo + (h.bounds[i]-lo)*(rank-float64(cum))/float64(c)
+	}
+	return h.bounds[len(h.bounds)-1]
+}
@@ -0,0 +1,52 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		h := ringbuffer.NewHistogram(0, []float64{1})
+		h.Push(1)
+		assert.Equal(0, h.Len())
+		assert.Equal(true, math.IsNaN(h.Quantile(0.5)))
+	}
+
+	h := ringbuffer.NewHistogram(4, []float64{10, 20, 40})
+	assert.Equal(4, h.Cap())
+	assert.Equal(true, math.IsNaN(h.Quantile(0.5)))
+	for _, v := range []float64{5, 15, 15, 30} {
+		h.Push(v)
+	}
+	assert.Equal([]int{1, 2, 1, 0}, h.Counts())
+	assert.Equal(true, math.IsNaN(h.Quantile(1.5)))
+	assert.Equal(true, math.IsNaN(h.Quantile(math.NaN())))
+
+	// half of the samples are at or below the middle of the second bucket
+	assert.InDelta(15.0, h.Quantile(0.5), 1e-9)
+	assert.InDelta(10.0, h.Quantile(0.25), 1e-9)
+	assert.InDelta(0.0, h.Quantile(0), 1e-9)
+	assert.InDelta(40.0, h.Quantile(1), 1e-9)
+
+	// old samples leave the window
+	h.Push(100)
+	h.Push(100)
+	assert.Equal([]int{0, 1, 1, 2}, h.Counts())
+	assert.Equal(40.0, h.Quantile(0.99))
+	assert.Equal(4, h.Len())
+}
+
+func ExampleHistogram() {
+	latency := ringbuffer.NewHistogram(1000, []float64{1, 2, 5, 10, 20, 50, 100})
+	for i := 0; i < 2000; i++ {
+		latency.Push(float64(i % 10))
+	}
+	fmt.Printf("p50=%.1f p99=%.1f\n", latency.Quantile(0.5), latency.Quantile(0.99))
+	// Output: p50=4.0 p99=9.9
+}
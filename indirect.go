@@ -0,0 +1,94 @@
+package ringbuffer
+
+const (
+	slotFree uint8 = iota
+	slotReserved
+	slotQueued
+	slotTaken
+)
+
+// FIFO queue of handles into caller-owned storage.
+//
+// Elements stay in the caller's slice and are never copied, only their indices travel through the ring. Slot reuse is
+// explicit: a producer reserves a free slot, fills it in place and commits it. A consumer pops the oldest committed
+// slot, works with it in place and releases it, after which it may be reserved again.
+type Indirect[T any] struct {
+	storage []T
+	state   []uint8
+	queue   RingBuffer[int]
+	free    RingBuffer[int]
+}
+
+// Create a new queue over the given storage. Capacity is len(storage), all slots start free.
+func NewIndirect[T any](storage []T) Indirect[T] {
+	b := Indirect[T]{
+		storage: storage,
+		state:   make([]uint8, len(storage)),
+		queue:   New[int](len(storage)),
+		free:    New[int](len(storage)),
+	}
+	for i := range storage {
+		b.free.Push(i)
+	}
+	return b
+}
+
+// How many slots the storage has?
+func (b Indirect[T]) Cap() int {
+	return len(b.storage)
+}
+
+// How many committed slots are waiting to be popped?
+func (b Indirect[T]) Len() int {
+	return b.queue.Len()
+}
+
+// Reserve a free slot for filling.
+//
+// Returns the slot handle, a pointer to the slot and true on success. Returns false if all slots are in use.
+func (b *Indirect[T]) Reserve() (int, *T, bool) {
+	h, ok := b.free.Pop()
+	if !ok {
+		return 0, nil, false
+	}
+	b.state[h] = slotReserved
+	return h, &b.storage[h], true
+}
+
+// Append a reserved slot to the queue.
+//
+// Returns false if the handle doesn't refer to a reserved slot.
+func (b *Indirect[T]) Commit(h int) bool {
+	if !b.is(h, slotReserved) {
+		return false
+	}
+	b.state[h] = slotQueued
+	return b.queue.Push(h)
+}
+
+// Try to pop the oldest committed slot. The slot must be released when the caller is done with it.
+//
+// Returns the slot handle, a pointer to the slot and true on success. Returns false if the queue was empty.
+func (b *Indirect[T]) Pop() (int, *T, bool) {
+	h, ok := b.queue.Pop()
+	if !ok {
+		return 0, nil, false
+	}
+	b.state[h] = slotTaken
+	return h, &b.storage[h], true
+}
+
+// Return a popped or reserved slot to the free list.
+//
+// Returns false if the handle doesn't refer to a popped or reserved slot.
+func (b *Indirect[T]) Release(h int) bool {
+	if !b.is(h, slotTaken) && !b.is(h, slotReserved) {
+		return false
+	}
+	b.state[h] = slotFree
+	return b.free.Push(h)
+}
+
+func (b *Indirect[T]) is(h int, state uint8) bool {
+	return h >= 0 && h < len(b.state) && b.state[h] == state
+}
@@ -0,0 +1,79 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type frame struct {
+	seq  int
+	data [256]byte
+}
+
+func TestIndirect(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var b ringbuffer.Indirect[frame]
+		_, _, ok := b.Reserve()
+		assert.Equal(false, ok)
+		_, _, ok = b.Pop()
+		assert.Equal(false, ok)
+		assert.Equal(false, b.Commit(0))
+	}
+
+	storage := make([]frame, 2)
+	b := ringbuffer.NewIndirect(storage)
+	assert.Equal(2, b.Cap())
+
+	h1, f1, ok := b.Reserve()
+	assert.Equal(true, ok)
+	f1.seq = 1
+	h2, f2, _ := b.Reserve()
+	f2.seq = 2
+	_, _, ok = b.Reserve()
+	assert.Equal(false, ok)
+
+	assert.Equal(true, b.Commit(h2))
+	assert.Equal(false, b.Commit(h2))
+	assert.Equal(true, b.Commit(h1))
+	assert.Equal(2, b.Len())
+
+	h, f, ok := b.Pop()
+	assert.Equal(true, ok)
+	assert.Equal(h2, h)
+	assert.Equal(2, f.seq)
+	assert.Same(&storage[h2], f)
+
+	// popped slot is not reusable until released
+	_, _, ok = b.Reserve()
+	assert.Equal(false, ok)
+	assert.Equal(false, b.Release(h1))
+	assert.Equal(true, b.Release(h))
+	assert.Equal(false, b.Release(h))
+	assert.Equal(false, b.Release(-1))
+
+	h3, _, ok := b.Reserve()
+	assert.Equal(true, ok)
+	assert.Equal(h2, h3)
+	assert.Equal(true, b.Release(h3))
+
+	_, f, _ = b.Pop()
+	assert.Equal(1, f.seq)
+}
+
+func ExampleIndirect() {
+	storage := make([]frame, 8)
+	b := ringbuffer.NewIndirect(storage)
+
+	h, f, _ := b.Reserve()
+	f.seq = 42
+	b.Commit(h)
+
+	h, f, _ = b.Pop()
+	fmt.Println(f.seq)
+	b.Release(h)
+	// Output: 42
+}
@@ -0,0 +1,80 @@
+package ringbuffer
+
+import (
+	"sync"
+)
+
+// Conflating single slot buffer safe for concurrent use, holding only the latest pushed value.
+//
+// Push always succeeds, replacing the pending value if there is one. Pop takes the most recent value. Useful for UI
+// state or sensor readings, where a consumer only cares about the current value and any value it didn't get to is
+// superseded. The zero value is an empty mailbox ready to use. Must not be copied after first use.
+type Mailbox[T any] struct {
+	mu       sync.Mutex
+	v        T
+	full     bool
+	replaced uint64
+	notify   notifier
+}
+
+// How many values are currently pending, 0 or 1?
+func (m *Mailbox[T]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.full {
+		return 1
+	}
+	return 0
+}
+
+// Push a new value to the mailbox, replacing the pending one.
+//
+// Returns true if a pending value was replaced. Returns false if the mailbox was empty.
+func (m *Mailbox[T]) Push(v T) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	was := m.full
+	m.v = v
+	m.full = true
+	if was {
+		m.replaced++
+	}
+	m.notify.update(!was, false, false, false)
+	return was
+}
+
+// Try to pop the pending value, leaving the mailbox empty.
+//
+// Returns the value and true on success. Returns default value and false if there was no pending value.
+func (m *Mailbox[T]) Pop() (T, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var def T
+	v, ok := m.v, m.full
+	m.v = def
+	m.full = false
+	return v, ok
+}
+
+// Look at the pending value without removing it from the mailbox.
+//
+// Returns the value and true on success. Returns default value and false if there was no pending value.
+func (m *Mailbox[T]) Peek() (T, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.v, m.full
+}
+
+// How many pending values were replaced by newer ones before being popped so far?
+func (m *Mailbox[T]) Replaced() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.replaced
+}
+
+// Get a channel receiving a value when the mailbox goes from empty to holding a value. See Sync.Readable.
+func (m *Mailbox[T]) Readable() <-chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.notify.readableChan(m.full)
+}
@@ -0,0 +1,50 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMailbox(t *testing.T) {
+	assert := assert.New(t)
+
+	var m ringbuffer.Mailbox[int]
+	_, ok := m.Pop()
+	assert.Equal(false, ok)
+	ready := m.Readable()
+	assert.Equal(false, fired(ready))
+
+	assert.Equal(false, m.Push(1))
+	assert.Equal(true, fired(ready))
+	assert.Equal(true, m.Push(2))
+	assert.Equal(true, m.Push(3))
+	assert.Equal(false, fired(ready))
+	assert.Equal(1, m.Len())
+	v, ok := m.Peek()
+	assert.Equal(true, ok)
+	assert.Equal(3, v)
+
+	// the latest value wins
+	v, ok = m.Pop()
+	assert.Equal(true, ok)
+	assert.Equal(3, v)
+	assert.Equal(0, m.Len())
+	_, ok = m.Pop()
+	assert.Equal(false, ok)
+	assert.Equal(uint64(2), m.Replaced())
+
+	m.Push(4)
+	assert.Equal(true, fired(ready))
+}
+
+func ExampleMailbox() {
+	var temperature ringbuffer.Mailbox[float64]
+	for _, v := range []float64{20.5, 21, 21.5} {
+		temperature.Push(v)
+	}
+	v, _ := temperature.Pop()
+	fmt.Println(v, temperature.Replaced())
+	// Output: 21.5 2
+}
@@ -0,0 +1,186 @@
+package ringbuffer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+)
+
+// Version of the binary snapshot format written by MarshalBinary.
+const snapshotVersion = 1
+
+var errBadSnapshot = errors.New("ringbuffer: malformed binary snapshot")
+
+// Largest backing storage in bytes a decoded buffer may allocate. Encodings which don't carry the whole storage only
+// state the capacity, a few bytes of input could ask for an arbitrarily large allocation otherwise.
+const maxDecodedStorage = 1 << 30
+
+// Check that a buffer of decoded capacity can be allocated: capacity+1 slots must fit in int and, unless T has zero
+// size, in maxDecodedStorage bytes.
+func checkCapacity[T any](capacity uint64) error {
+	var def T
+	size := uint64(unsafe.Sizeof(def))
+	if capacity >= math.MaxInt || (size > 0 && capacity >= maxDecodedStorage/size) {
+		return fmt.Errorf("ringbuffer: capacity %d too large", capacity)
+	}
+	return nil
+}
+
+type jsonRingBuffer[T any] struct {
+	Capacity int `json:"capacity"`
+	Elements []T `json:"elements"`
+}
+
+type gobRingBuffer[T any] struct {
+	Capacity int
+	Policy   OverflowPolicy
+	Elements []T
+}
+
+// Encode the buffer as a JSON object with its capacity and stored elements in FIFO order.
+func (b RingBuffer[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonRingBuffer[T]{
+		Capacity: b.Cap(),
+		Elements: b.AppendTo(make([]T, 0, b.Len())),
+	})
+}
+
+// Replace the buffer with one decoded from a JSON object produced by MarshalJSON. The overflow policy is kept.
+//
+// Capacities needing more than 1 GiB of storage are rejected, the input only states the capacity.
+func (b *RingBuffer[T]) UnmarshalJSON(data []byte) error {
+	var v jsonRingBuffer[T]
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Capacity >= 0 {
+		if err := checkCapacity[T](uint64(v.Capacity)); err != nil {
+			return err
+		}
+	}
+	if v.Capacity < 0 || len(v.Elements) > v.Capacity {
+		return fmt.Errorf("ringbuffer: %d elements don't fit capacity %d", len(v.Elements), v.Capacity)
+	}
+	nb := NewFrom(v.Capacity, v.Elements)
+	nb.gen = b.gen + 1 // contents replaced, invalidate read cursors
+	nb.policy = b.policy
+	*b = nb
+	return nil
+}
+
+// Encode the full buffer state, including cursors and overflow policy, for restoring with UnmarshalBinary.
+//
+// The snapshot starts with a version byte and the policy byte, followed by capacity, read and write positions and the
+// sequence number as uvarints, and the whole backing slice in little endian encoding/binary format. T must be a fixed
+// size type as accepted by encoding/binary.
+func (b RingBuffer[T]) MarshalBinary() ([]byte, error) {
+	out := []byte{snapshotVersion, byte(b.policy)}
+	out = binary.AppendUvarint(out, uint64(b.Cap()))
+	out = binary.AppendUvarint(out, uint64(b.read))
+	out = binary.AppendUvarint(out, uint64(b.write))
+	out = binary.AppendUvarint(out, b.seq)
+	if len(b.buffer) == 0 {
+		return out, nil
+	}
+	return binary.Append(out, binary.LittleEndian, b.buffer)
+}
+
+// Replace the buffer with the state decoded from a snapshot produced by MarshalBinary.
+func (b *RingBuffer[T]) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errBadSnapshot
+	}
+	if data[0] != snapshotVersion {
+		return fmt.Errorf("ringbuffer: unsupported binary snapshot version %d", data[0])
+	}
+	policy := OverflowPolicy(data[1])
+	data = data[2:]
+	var fields [4]uint64
+	for i := range fields {
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errBadSnapshot
+		}
+		fields[i], data = v, data[n:]
+	}
+	capacity, read, write, seq := fields[0], fields[1], fields[2], fields[3]
+
+	var nb RingBuffer[T]
+	if capacity == 0 {
+		if read != 0 || write != 0 || len(data) != 0 {
+			return errBadSnapshot
+		}
+	} else {
+		var def T
+		size := binary.Size(def)
+		if size < 0 {
+			return fmt.Errorf("ringbuffer: %T is not a fixed size type", def)
+		}
+		// capacity+1 slots must fit in int for any T, a zero size T doesn't take any input to back them
+		if capacity >= math.MaxInt || read > capacity || write > capacity {
+			return errBadSnapshot
+		}
+		if size > 0 && capacity >= uint64(len(data)/size) {
+			return errBadSnapshot
+		}
+		nb = New[T](int(capacity))
+		if size == 0 && len(data) != 0 {
+			return errBadSnapshot
+		}
+		if size > 0 { // nothing to decode otherwise, and no point in visiting every slot
+			n, err := binary.Decode(data, binary.LittleEndian, nb.buffer)
+			if err != nil {
+				return err
+			}
+			if n != len(data) {
+				return errBadSnapshot
+			}
+		}
+		nb.read, nb.write = int(read), int(write)
+	}
+	nb.seq = seq
+	nb.gen = b.gen + 1 // contents replaced, invalidate read cursors
+	nb.policy = policy
+	*b = nb
+	return nil
+}
+
+// Encode the capacity, overflow policy and stored elements in FIFO order with encoding/gob.
+//
+// Takes precedence over MarshalBinary in gob streams, so any T supported by gob works. As usual with gob, interface
+// element types need their concrete types registered.
+func (b RingBuffer[T]) GobEncode() ([]byte, error) {
+	var out bytes.Buffer
+	err := gob.NewEncoder(&out).Encode(gobRingBuffer[T]{
+		Capacity: b.Cap(),
+		Policy:   b.policy,
+		Elements: b.AppendTo(nil),
+	})
+	return out.Bytes(), err
+}
+
+// Replace the buffer with one decoded from data produced by GobEncode. Capacities are checked as by UnmarshalJSON.
+func (b *RingBuffer[T]) GobDecode(data []byte) error {
+	var v gobRingBuffer[T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v); err != nil {
+		return err
+	}
+	if v.Capacity >= 0 {
+		if err := checkCapacity[T](uint64(v.Capacity)); err != nil {
+			return err
+		}
+	}
+	if v.Capacity < 0 || len(v.Elements) > v.Capacity {
+		return fmt.Errorf("ringbuffer: %d elements don't fit capacity %d", len(v.Elements), v.Capacity)
+	}
+	nb := NewFrom(v.Capacity, v.Elements)
+	nb.gen = b.gen + 1 // contents replaced, invalidate read cursors
+	nb.policy = v.Policy
+	*b = nb
+	return nil
+}
@@ -0,0 +1,187 @@
+package ringbuffer_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestRingBufferJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		data, err := json.Marshal(buf)
+		assert.NoError(err)
+		assert.Equal(`{"capacity":0,"elements":[]}`, string(data))
+	}
+
+	buf := ringbuffer.New[int](4)
+	// move cursors so that contents wrap around
+	for i := 0; i < 3; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	buf.PushMany([]int{1, 2, 3})
+	data, err := json.Marshal(buf)
+	assert.NoError(err)
+	assert.Equal(`{"capacity":4,"elements":[1,2,3]}`, string(data))
+
+	out := ringbuffer.NewWithOptions[int](1, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest))
+	r := out.ReadCursor()
+	assert.NoError(json.Unmarshal(data, &out))
+	assert.Equal(false, r.Valid())
+	assert.Equal(4, out.Cap())
+	assert.Equal([]int{1, 2, 3}, contents(&out))
+	out.Push(4)
+	out.Push(5)
+	assert.Equal([]int{2, 3, 4, 5}, contents(&out))
+
+	// embedded in other structs
+	var state struct {
+		Recent ringbuffer.RingBuffer[string] `json:"recent"`
+	}
+	assert.NoError(json.Unmarshal([]byte(`{"recent":{"capacity":2,"elements":["a"]}}`), &state))
+	assert.Equal([]string{"a"}, contents(&state.Recent))
+
+	assert.Error(json.Unmarshal([]byte(`{"capacity":1,"elements":[1,2]}`), &out))
+	assert.Error(json.Unmarshal([]byte(`{"capacity":-1}`), &out))
+
+	// huge capacities are rejected instead of allocated
+	assert.Error(json.Unmarshal([]byte(`{"capacity":9223372036854775807}`), &out))
+	assert.Error(json.Unmarshal([]byte(`{"capacity":1000000000}`), &out))
+	var empty ringbuffer.RingBuffer[struct{}]
+	assert.Error(json.Unmarshal([]byte(`{"capacity":9223372036854775807}`), &empty))
+	assert.NoError(json.Unmarshal([]byte(`{"capacity":1000000000,"elements":[{}]}`), &empty))
+	assert.Equal(1000000000, empty.Cap())
+	assert.Error(json.Unmarshal([]byte(`[]`), &out))
+	assert.Equal([]int{2, 3, 4, 5}, contents(&out))
+}
+
+func TestRingBufferBinary(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int32]
+		data, err := buf.MarshalBinary()
+		assert.NoError(err)
+		var out ringbuffer.RingBuffer[int32]
+		assert.NoError(out.UnmarshalBinary(data))
+		assert.Equal(0, out.Cap())
+	}
+
+	buf := ringbuffer.NewWithOptions[int32](4, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest))
+	// move cursors so that contents wrap around
+	for i := 0; i < 3; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	buf.PushMany([]int32{1, 2, 3})
+	r := buf.ReadCursor()
+	r.Next()
+	data, err := buf.MarshalBinary()
+	assert.NoError(err)
+	assert.Equal(byte(1), data[0])
+
+	var out ringbuffer.RingBuffer[int32]
+	old := out.ReadCursor()
+	assert.NoError(out.UnmarshalBinary(data))
+	assert.Equal(false, old.Valid())
+	assert.Equal(4, out.Cap())
+	assert.Equal([]int32{1, 2, 3}, contents(&out))
+	out.PushMany([]int32{4, 5})
+	assert.Equal([]int32{2, 3, 4, 5}, contents(&out))
+
+	// restoring over a non-empty buffer
+	buf.Pop()
+	again, _ := buf.MarshalBinary()
+	assert.NoError(out.UnmarshalBinary(again))
+	assert.Equal([]int32{2, 3}, contents(&out))
+
+	// malformed snapshots
+	for _, bad := range [][]byte{nil, {2, 0}, {1, 0}, data[:len(data)-1], append(data, 0)} {
+		assert.Error(out.UnmarshalBinary(bad))
+	}
+	assert.Equal([]int32{2, 3}, contents(&out))
+
+	// capacities not fitting in int, there is no input to check them against for zero size types
+	var empty ringbuffer.RingBuffer[struct{}]
+	for _, capacity := range []uint64{math.MaxUint64, math.MaxInt, math.MaxInt - 1, 1000} {
+		snapshot := binary.AppendUvarint([]byte{1, 0}, capacity)
+		snapshot = append(snapshot, 0, 0, 0)
+		if capacity < math.MaxInt {
+			assert.NoError(empty.UnmarshalBinary(snapshot))
+			assert.Equal(int(capacity), empty.Cap())
+		} else {
+			assert.Error(empty.UnmarshalBinary(snapshot))
+		}
+	}
+
+	_, err = ringbuffer.New[int](1).MarshalBinary()
+	assert.Error(err)
+	var ints ringbuffer.RingBuffer[int]
+	assert.Error(ints.UnmarshalBinary(data))
+}
+
+func TestRingBufferGob(t *testing.T) {
+	assert := assert.New(t)
+
+	type worker struct {
+		Name    string
+		Pending ringbuffer.RingBuffer[string]
+		Empty   ringbuffer.RingBuffer[int]
+	}
+
+	in := worker{
+		Name:    "w1",
+		Pending: ringbuffer.NewWithOptions[string](3, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest)),
+	}
+	// move cursors so that contents wrap around
+	for i := 0; i < 3; i++ {
+		in.Pending.Push("")
+		in.Pending.Pop()
+	}
+	in.Pending.PushMany([]string{"a", "b", "c"})
+
+	var stream bytes.Buffer
+	assert.NoError(gob.NewEncoder(&stream).Encode(in))
+	var out worker
+	assert.NoError(gob.NewDecoder(&stream).Decode(&out))
+	assert.Equal("w1", out.Name)
+	assert.Equal(3, out.Pending.Cap())
+	assert.Equal([]string{"a", "b", "c"}, contents(&out.Pending))
+	assert.Equal(0, out.Empty.Cap())
+
+	// policy is kept
+	out.Pending.Push("d")
+	assert.Equal([]string{"b", "c", "d"}, contents(&out.Pending))
+
+	assert.Error(out.Pending.GobDecode([]byte("garbage")))
+
+	// huge capacities are rejected instead of allocated
+	for _, capacity := range []int{math.MaxInt, 1 << 30, -1} {
+		var huge bytes.Buffer
+		gob.NewEncoder(&huge).Encode(struct {
+			Capacity int
+			Elements []string
+		}{capacity, []string{"a"}})
+		assert.Error(out.Pending.GobDecode(huge.Bytes()))
+	}
+	assert.Equal([]string{"b", "c", "d"}, contents(&out.Pending))
+	assert.Equal([]string{"b", "c", "d"}, contents(&out.Pending))
+}
+
+func ExampleRingBuffer_MarshalJSON() {
+	b := ringbuffer.New[string](3)
+	b.Push("x")
+	b.Push("y")
+	data, _ := json.Marshal(b)
+	fmt.Println(string(data))
+	// Output: {"capacity":3,"elements":["x","y"]}
+}
@@ -0,0 +1,47 @@
+package ringbuffer
+
+import (
+	"unsafe"
+)
+
+// Memory usage of a buffer, in bytes.
+type MemStats struct {
+	Backing  int // size of the backing slice, all slots included
+	Live     int // estimated size of stored elements
+	Retained int // estimated size of popped elements still referenced from free slots
+}
+
+// Report memory usage of the buffer.
+//
+// Backing size only accounts for slots themselves. Other estimates use the optional size function, which should
+// report the memory an element references beyond its slot (e.g. len of a byte slice). Without it, Live counts slot
+// sizes of stored elements and Retained is zero.
+//
+// Pop doesn't clear slots, so popped elements keep whatever they reference alive until the slot is reused. Retained
+// shows how much memory is held that way.
+func (b RingBuffer[T]) MemStats(size func(T) int) MemStats {
+	var def T
+	slot := int(unsafe.Sizeof(def))
+	s := MemStats{
+		Backing: len(b.buffer) * slot,
+		Live:    b.Len() * slot,
+	}
+	if size == nil {
+		return s
+	}
+
+	x, y := b.Regions()
+	live := 0
+	for _, r := range [2][]T{x, y} {
+		for _, v := range r {
+			live += size(v)
+		}
+	}
+	all := 0
+	for _, v := range b.buffer {
+		all += size(v)
+	}
+	s.Live += live
+	s.Retained = all - live
+	return s
+}
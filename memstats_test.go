@@ -0,0 +1,43 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"unsafe"
+)
+
+func TestMemStats(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int64]
+		assert.Equal(ringbuffer.MemStats{}, buf.MemStats(nil))
+	}
+
+	ints := ringbuffer.New[int64](3)
+	ints.Push(1)
+	ints.Push(2)
+	assert.Equal(ringbuffer.MemStats{Backing: 32, Live: 16}, ints.MemStats(nil))
+
+	size := func(p []byte) int { return cap(p) }
+	slot := int(unsafe.Sizeof([]byte{}))
+	bufs := ringbuffer.New[[]byte](3)
+	bufs.Push(make([]byte, 100))
+	bufs.Push(make([]byte, 10))
+	bufs.Pop()
+	assert.Equal(ringbuffer.MemStats{
+		Backing:  4 * slot,
+		Live:     slot + 10,
+		Retained: 100,
+	}, bufs.MemStats(size))
+}
+
+func ExampleRingBuffer_MemStats() {
+	b := ringbuffer.New[[]byte](4)
+	b.Push(make([]byte, 1024))
+	b.Pop()
+	fmt.Println(b.MemStats(func(p []byte) int { return cap(p) }).Retained)
+	// Output: 1024
+}
@@ -0,0 +1,131 @@
+package ringbuffer
+
+import (
+	"time"
+)
+
+// Recent push and pop rates in events per second.
+type Rates struct {
+	Push float64
+	Pop  float64
+}
+
+// Number of time buckets a metering window is split into.
+const meterBuckets = 10
+
+type meterBucket struct {
+	slot int64
+	push uint64
+	pop  uint64
+}
+
+// Fixed length FIFO ring buffer which measures recent throughput.
+//
+// Successful pushes and pops are counted in a small ring of time buckets covering the measurement window, the
+// oldest bucket is reused as time advances. Buckets are numbered from the first time the buffer is used, so any
+// clock reading works, including times before 1970.
+type Metered[T any] struct {
+	buf     RingBuffer[T]
+	width   time.Duration
+	buckets [meterBuckets]meterBucket
+	clock   Clock
+	start   time.Time
+	started bool
+}
+
+// Create a new buffer which can store capacity elements and measures rates over the given window.
+func NewMetered[T any](capacity int, window time.Duration) Metered[T] {
+	return Metered[T]{
+		buf:   New[T](capacity),
+		width: max(window/meterBuckets, 1),
+	}
+}
+
+// Use the given clock instead of the system clock. Nil restores the system clock.
+func (b *Metered[T]) SetClock(c Clock) {
+	b.clock = c
+}
+
+// How many elements a buffer can store?
+func (b Metered[T]) Cap() int {
+	return b.buf.Cap()
+}
+
+// How many elements are currently stored in the buffer?
+func (b Metered[T]) Len() int {
+	return b.buf.Len()
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *Metered[T]) Push(v T) bool {
+	if !b.buf.Push(v) {
+		return false
+	}
+	b.bucket(clockNow(b.clock)).push++
+	return true
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *Metered[T]) Pop() (T, bool) {
+	v, ok := b.buf.Pop()
+	if ok {
+		b.bucket(clockNow(b.clock)).pop++
+	}
+	return v, ok
+}
+
+// Get push and pop rates over the measurement window ending now.
+func (b *Metered[T]) Rates() Rates {
+	if b.width <= 0 {
+		return Rates{}
+	}
+	now := b.since(clockNow(b.clock))
+	cur := floorDiv(now, int64(b.width))
+	var push, pop uint64
+	for _, bk := range b.buckets {
+		if bk.slot > cur-meterBuckets && bk.slot <= cur {
+			push += bk.push
+			pop += bk.pop
+		}
+	}
+	elapsed := time.Duration((meterBuckets-1)*int64(b.width) + now - cur*int64(b.width))
+	secs := elapsed.Seconds()
+	if secs <= 0 {
+		return Rates{}
+	}
+	return Rates{
+		Push: float64(push) / secs,
+		Pop:  float64(pop) / secs,
+	}
+}
+
+func (b *Metered[T]) bucket(t time.Time) *meterBucket {
+	slot := floorDiv(b.since(t), int64(b.width))
+	bk := &b.buckets[slot-floorDiv(slot, meterBuckets)*meterBuckets]
+	if bk.slot != slot {
+		*bk = meterBucket{slot: slot}
+	}
+	return bk
+}
+
+// Get nanoseconds passed since the first use of the buffer.
+func (b *Metered[T]) since(t time.Time) int64 {
+	if !b.started {
+		b.start = t
+		b.started = true
+	}
+	return int64(t.Sub(b.start))
+}
+
+// Integer division rounding towards negative infinity, so that times before the start fall into earlier slots.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,62 @@
+package ringbuffer_test
+
+import (
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMetered(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var b ringbuffer.Metered[int]
+		assert.Equal(false, b.Push(1))
+		assert.Equal(ringbuffer.Rates{}, b.Rates())
+	}
+
+	b := ringbuffer.NewMetered[int](10, time.Hour)
+	assert.Equal(ringbuffer.Rates{}, b.Rates())
+	for i := 0; i < 12; i++ {
+		b.Push(i)
+	}
+	for i := 0; i < 5; i++ {
+		b.Pop()
+	}
+	assert.Equal(10, b.Cap())
+	assert.Equal(5, b.Len())
+
+	r := b.Rates()
+	assert.Greater(r.Push, 0.0)
+	assert.LessOrEqual(r.Push, 10/(54*time.Minute).Seconds())
+	assert.InDelta(2.0, r.Push/r.Pop, 1e-9)
+
+	// window of 10 seconds, one second buckets
+	clock := ringbuffer.NewManualClock(time.Unix(1000, 0))
+	b = ringbuffer.NewMetered[int](100, 10*time.Second)
+	b.SetClock(clock)
+	for i := 0; i < 20; i++ {
+		b.Push(i)
+		clock.Advance(500 * time.Millisecond)
+	}
+	// the current bucket just started, 18 pushes in the 9 seconds before it count
+	assert.Equal(ringbuffer.Rates{Push: 2}, b.Rates())
+	clock.Advance(500 * time.Millisecond)
+	assert.Equal(ringbuffer.Rates{Push: 18 / 9.5}, b.Rates())
+	clock.Advance(time.Minute)
+	assert.Equal(ringbuffer.Rates{}, b.Rates())
+
+	// clocks before 1970, or at the zero time
+	for _, start := range []time.Time{{}, time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)} {
+		clock := ringbuffer.NewManualClock(start)
+		b := ringbuffer.NewMetered[int](100, 10*time.Second)
+		b.SetClock(clock)
+		for i := 0; i < 20; i++ {
+			assert.Equal(true, b.Push(i))
+			clock.Advance(500 * time.Millisecond)
+		}
+		b.Pop()
+		assert.Equal(ringbuffer.Rates{Push: 2, Pop: 1 / 9.0}, b.Rates())
+	}
+}
@@ -0,0 +1,152 @@
+package ringbuffer
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Tap which copies every element pushed to a source buffer into a destination buffer in a background goroutine.
+//
+// Producers of the source are never slowed down by the destination: pushes only queue a copy, consumers of the source
+// keep popping as usual. Copies reach the destination in push order, pushed according to the destination policy.
+// Elements merged by PushMerge are copied as pushed. Create one with Sync.Mirror or Blocking.Mirror.
+type Mirror[T any] struct {
+	mu      sync.Mutex
+	pending []T
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	detach  func()
+	once    sync.Once
+	dropped atomic.Uint64
+}
+
+func startMirror[T any](dst *Sync[T], policy OverflowPolicy, detach func()) *Mirror[T] {
+	m := &Mirror[T]{
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		detach: detach,
+	}
+	go func() {
+		defer close(m.done)
+		var batch []T
+		for {
+			select {
+			case <-m.wake:
+			case <-m.stop:
+				m.flush(dst, policy, batch)
+				return
+			}
+			batch = m.flush(dst, policy, batch)
+		}
+	}()
+	return m
+}
+
+// Queue copies of elements accepted by the source. The source lock must be held.
+func (m *Mirror[T]) add(vs ...T) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending = append(m.pending, vs...)
+	signal(m.wake)
+}
+
+// Push all queued copies to dst, reusing batch as the next queue.
+func (m *Mirror[T]) flush(dst *Sync[T], policy OverflowPolicy, batch []T) []T {
+	m.mu.Lock()
+	batch, m.pending = m.pending, batch[:0]
+	m.mu.Unlock()
+
+	for i, v := range batch {
+		if !dst.pushPolicy(v, policy) {
+			m.dropped.Add(1)
+		}
+		var def T
+		batch[i] = def
+	}
+	return batch
+}
+
+// How many copies were dropped because the destination had no room for them so far?
+func (m *Mirror[T]) Dropped() uint64 {
+	return m.dropped.Load()
+}
+
+// Stop copying and wait until copies of elements pushed before Stop reached the destination. Stopping a stopped
+// mirror does nothing.
+func (m *Mirror[T]) Stop() {
+	m.once.Do(func() {
+		m.detach()
+		close(m.stop)
+	})
+	<-m.done
+}
+
+// Start copying every element pushed to the buffer from now on into dst, pushed according to policy. See Mirror.
+func (b *Sync[T]) Mirror(dst *Sync[T], policy OverflowPolicy) *Mirror[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var m *Mirror[T]
+	m = startMirror(dst, policy, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.mirrors = unmirror(b.mirrors, m)
+	})
+	b.mirrors = append(b.mirrors, m)
+	return m
+}
+
+// Start copying every element pushed to the buffer from now on into dst, pushed according to policy. See Mirror.
+func (b *Blocking[T]) Mirror(dst *Sync[T], policy OverflowPolicy) *Mirror[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var m *Mirror[T]
+	m = startMirror(dst, policy, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.mirrors = unmirror(b.mirrors, m)
+	})
+	b.mirrors = append(b.mirrors, m)
+	return m
+}
+
+func unmirror[T any](ms []*Mirror[T], m *Mirror[T]) []*Mirror[T] {
+	for i, w := range ms {
+		if w == m {
+			return append(ms[:i], ms[i+1:]...)
+		}
+	}
+	return ms
+}
+
+// Queue copies of pushed elements for all mirrors. The lock must be held.
+func tap[T any](ms []*Mirror[T], vs ...T) {
+	for _, m := range ms {
+		m.add(vs...)
+	}
+}
+
+// Push a new element according to policy. Returns false if it was dropped.
+func (b *Sync[T]) pushPolicy(v T, policy OverflowPolicy) bool {
+	b.lock()
+	defer b.unlock()
+	if b.buf.Cap() == 0 {
+		return false
+	}
+	switch policy {
+	case DropOldest:
+		if !b.buf.canOverwrite() {
+			return false
+		}
+		b.buf.PushOverwrite(v)
+	case DropNewest:
+		b.buf.PushMerge(v, func(_, v T) T { return v })
+	default:
+		if !b.buf.Push(v) {
+			return false
+		}
+	}
+	tap(b.mirrors, v)
+	return true
+}
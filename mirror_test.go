@@ -0,0 +1,97 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestMirror(t *testing.T) {
+	assert := assert.New(t)
+
+	src := ringbuffer.NewSync[int](4)
+	dst := ringbuffer.NewSync[int](100)
+	m := src.Mirror(dst, ringbuffer.Reject)
+	src.Push(1)
+	src.PushMany([]int{2, 3, 4, 5})
+	src.PushFront(0)
+	src.Drain()
+	src.PushOverwrite(6)
+	src.PushMerge(7, func(a, b int) int { return a + b })
+	src.Push(8)
+	m.Stop()
+	src.Push(9)
+	m.Stop()
+
+	// copies of accepted elements only, the source is consumed as usual
+	assert.Equal([]int{1, 2, 3, 4, 6, 7, 8}, dst.Drain())
+	assert.Equal([]int{6, 7, 8, 9}, src.Drain())
+	assert.Equal(uint64(0), m.Dropped())
+
+	// destination policy
+	for _, c := range []struct {
+		policy  ringbuffer.OverflowPolicy
+		want    []int
+		dropped uint64
+	}{
+		{ringbuffer.Reject, []int{1, 2}, 2},
+		{ringbuffer.DropOldest, []int{3, 4}, 0},
+		{ringbuffer.DropNewest, []int{1, 4}, 0},
+	} {
+		src := ringbuffer.NewBlocking[int](4)
+		dst := ringbuffer.NewSync[int](2)
+		m := src.Mirror(dst, c.policy)
+		for i := 1; i <= 4; i++ {
+			src.Push(i)
+		}
+		m.Stop()
+		assert.Equal(c.want, dst.Drain())
+		assert.Equal(c.dropped, m.Dropped())
+	}
+}
+
+func TestMirrorConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	const n = 1000
+	src := ringbuffer.NewSync[int](8)
+	dst := ringbuffer.NewSync[int](2 * n)
+	m := src.Mirror(dst, ringbuffer.Reject)
+
+	var wg sync.WaitGroup
+	for p := range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= n; i++ {
+				src.PushOverwrite(p*n + i)
+			}
+		}()
+	}
+	wg.Wait()
+	m.Stop()
+
+	// everything is copied, in push order of each producer
+	got := dst.Drain()
+	assert.Equal(2*n, len(got))
+	last := []int{0, n}
+	for _, v := range got {
+		p := (v - 1) / n
+		assert.Equal(last[p]+1, v)
+		last[p] = v
+	}
+}
+
+func ExampleMirror() {
+	hot := ringbuffer.NewSync[string](2)
+	audit := ringbuffer.NewSync[string](16)
+	m := hot.Mirror(audit, ringbuffer.DropOldest)
+	hot.Push("login")
+	hot.Pop()
+	hot.Push("logout")
+	m.Stop()
+	fmt.Println(hot.Drain(), audit.Drain())
+	// Output: [logout] [login logout]
+}
@@ -0,0 +1,20 @@
+//go:build !unix && !windows
+
+package mmapring
+
+import (
+	"errors"
+	"os"
+)
+
+func mmap(f *os.File, size int) ([]byte, error) {
+	return nil, errors.ErrUnsupported
+}
+
+func munmap(m []byte) error {
+	return nil
+}
+
+func msync(f *os.File, m []byte) error {
+	return nil
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package mmapring
+
+import (
+	"os"
+	"syscall"
+)
+
+func mmap(f *os.File, size int) ([]byte, error) {
+	return syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+}
+
+func munmap(m []byte) error {
+	return syscall.Munmap(m)
+}
@@ -0,0 +1,37 @@
+//go:build windows
+
+package mmapring
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func mmap(f *os.File, size int) ([]byte, error) {
+	h, err := syscall.CreateFileMapping(syscall.Handle(f.Fd()), nil, syscall.PAGE_READWRITE, 0, 0, nil)
+	if err != nil {
+		return nil, os.NewSyscallError("CreateFileMapping", err)
+	}
+	// the view keeps the mapping object alive
+	defer syscall.CloseHandle(h)
+	addr, err := syscall.MapViewOfFile(h, syscall.FILE_MAP_WRITE, 0, 0, uintptr(size))
+	if err != nil {
+		return nil, os.NewSyscallError("MapViewOfFile", err)
+	}
+	// the view is not Go heap memory, reinterpret the address without a uintptr conversion
+	ptr := *(*unsafe.Pointer)(unsafe.Pointer(&addr))
+	return unsafe.Slice((*byte)(ptr), size), nil
+}
+
+func munmap(m []byte) error {
+	return syscall.UnmapViewOfFile(uintptr(unsafe.Pointer(&m[0])))
+}
+
+func msync(f *os.File, m []byte) error {
+	if err := syscall.FlushViewOfFile(uintptr(unsafe.Pointer(&m[0])), uintptr(len(m))); err != nil {
+		return os.NewSyscallError("FlushViewOfFile", err)
+	}
+	// flushing the view only starts writing, wait for the file contents to hit the disk
+	return f.Sync()
+}
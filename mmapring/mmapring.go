@@ -0,0 +1,231 @@
+// Package provides a fixed length FIFO ring buffer stored in a memory-mapped file.
+//
+// Storage and cursors live in the file itself, every push and pop updates the mapping directly. The contents survive
+// process restarts without any serialization step: opening the same file again continues where the last process
+// stopped. Changes reach the file through the kernel page cache, they survive a process crash but not necessarily a
+// system crash, use Ring.Sync to make them durable.
+//
+// Every slot carries a checksum of its contents and its position. A system crash may persist an advanced write
+// position without the slot it covers, Open detects that, even if the slot still holds valid contents from the
+// previous lap, and truncates the ring at the first damaged slot, see Discarded.
+//
+// SPSC uses the same approach to share a ring between a producer and a consumer process.
+//
+// File layout of Ring, all integers little endian:
+//
+//	offset  size  field
+//	0       4     magic "RBMM"
+//	4       4     format version
+//	8       8     slot size in bytes
+//	16      8     number of slots
+//	24      8     read position, counts slots popped so far
+//	32      8     write position, counts slots pushed so far
+//	40      4     CRC-32 (IEEE) of the first 24 bytes
+//	64            slots
+//
+// Every slot is stored as slot size bytes of contents followed by a 4 byte CRC-32 (IEEE) of the 8 byte position of the
+// slot, counting slots pushed before it, followed by the contents.
+package mmapring
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+)
+
+const (
+	magic      = "RBMM"
+	version    = 2
+	headerSize = 64
+	crcSize    = 4
+
+	offVersion  = 4
+	offSlotSize = 8
+	offSlots    = 16
+	offRead     = 24
+	offWrite    = 32
+	offCRC      = 40
+)
+
+// Returned by Open for files which don't hold a ring buffer of the requested geometry.
+var ErrMismatch = errors.New("mmapring: file does not match ring geometry")
+
+// Ring buffer of fixed size slots stored in a memory-mapped file. Not safe for concurrent use.
+type Ring struct {
+	f         *os.File
+	m         []byte
+	slotSize  int
+	slots     int
+	discarded int
+}
+
+// Open or create a ring buffer file with the given number of slots of slotSize bytes each.
+//
+// A new or empty file is initialized. An existing ring file must have the same slot size and number of slots,
+// otherwise ErrMismatch is returned.
+func Open(path string, slotSize, slots int) (*Ring, error) {
+	if slotSize < 1 || slots < 1 {
+		return nil, fmt.Errorf("mmapring: invalid geometry %d x %d", slots, slotSize)
+	}
+	size := headerSize + (slotSize+crcSize)*slots
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	fresh := fi.Size() == 0
+	if fresh {
+		if err := f.Truncate(int64(size)); err != nil {
+			f.Close()
+			return nil, err
+		}
+	} else if fi.Size() != int64(size) {
+		f.Close()
+		return nil, ErrMismatch
+	}
+	m, err := mmap(f, size)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r := &Ring{f: f, m: m, slotSize: slotSize, slots: slots}
+	if fresh {
+		copy(m, magic)
+		binary.LittleEndian.PutUint32(m[offVersion:], version)
+		binary.LittleEndian.PutUint64(m[offSlotSize:], uint64(slotSize))
+		binary.LittleEndian.PutUint64(m[offSlots:], uint64(slots))
+		binary.LittleEndian.PutUint32(m[offCRC:], crc32.ChecksumIEEE(m[:offRead]))
+	} else if err := r.check(); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Ring) check() error {
+	if string(r.m[:len(magic)]) != magic {
+		return ErrMismatch
+	}
+	if crc32.ChecksumIEEE(r.m[:offRead]) != binary.LittleEndian.Uint32(r.m[offCRC:]) {
+		return errors.New("mmapring: corrupt header")
+	}
+	if v := binary.LittleEndian.Uint32(r.m[offVersion:]); v != version {
+		return fmt.Errorf("mmapring: unsupported format version %d", v)
+	}
+	if binary.LittleEndian.Uint64(r.m[offSlotSize:]) != uint64(r.slotSize) ||
+		binary.LittleEndian.Uint64(r.m[offSlots:]) != uint64(r.slots) {
+		return ErrMismatch
+	}
+	if n := r.write() - r.read(); n > uint64(r.slots) {
+		return fmt.Errorf("mmapring: corrupt cursors, %d elements in %d slots", n, r.slots)
+	}
+	r.recover()
+	return nil
+}
+
+// Verify stored slots, moving the write position back to the first damaged one.
+func (r *Ring) recover() {
+	w := r.write()
+	for pos := r.read(); pos < w; pos++ {
+		if slotSum(pos, r.slot(pos)) != binary.LittleEndian.Uint32(r.slotCRC(pos)) {
+			r.discarded = int(w - pos)
+			binary.LittleEndian.PutUint64(r.m[offWrite:], pos)
+			return
+		}
+	}
+}
+
+func (r *Ring) read() uint64  { return binary.LittleEndian.Uint64(r.m[offRead:]) }
+func (r *Ring) write() uint64 { return binary.LittleEndian.Uint64(r.m[offWrite:]) }
+
+func (r *Ring) slot(pos uint64) []byte {
+	off := headerSize + int(pos%uint64(r.slots))*(r.slotSize+crcSize)
+	return r.m[off : off+r.slotSize]
+}
+
+func (r *Ring) slotCRC(pos uint64) []byte {
+	off := headerSize + int(pos%uint64(r.slots))*(r.slotSize+crcSize) + r.slotSize
+	return r.m[off : off+crcSize]
+}
+
+// Checksum of slot contents at a position, so that contents left over from a previous lap don't pass as valid.
+func slotSum(pos uint64, s []byte) uint32 {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], pos)
+	return crc32.Update(crc32.ChecksumIEEE(b[:]), crc32.IEEETable, s)
+}
+
+// How many slots a buffer can store?
+func (r *Ring) Cap() int {
+	return r.slots
+}
+
+// How many slots are currently stored in the buffer?
+func (r *Ring) Len() int {
+	return int(r.write() - r.read())
+}
+
+// How many slots were lost to a crash and discarded when the ring was opened?
+func (r *Ring) Discarded() int {
+	return r.discarded
+}
+
+// Size of a slot in bytes.
+func (r *Ring) SlotSize() int {
+	return r.slotSize
+}
+
+// Push a new element to the buffer, p is stored in a slot padded with zeroes. Panics if p is longer than a slot.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (r *Ring) Push(p []byte) bool {
+	if len(p) > r.slotSize {
+		panic("mmapring: element larger than slot")
+	}
+	w := r.write()
+	if w-r.read() == uint64(r.slots) {
+		return false // no more space
+	}
+	s := r.slot(w)
+	clear(s[copy(s, p):])
+	binary.LittleEndian.PutUint32(r.slotCRC(w), slotSum(w, s))
+	binary.LittleEndian.PutUint64(r.m[offWrite:], w+1)
+	return true
+}
+
+// Try to pop an element from the buffer, copying the slot into dst. Panics if dst is shorter than a slot.
+//
+// Returns true on success. Returns false if there were no elements in the buffer.
+func (r *Ring) Pop(dst []byte) bool {
+	if len(dst) < r.slotSize {
+		panic("mmapring: destination shorter than slot")
+	}
+	rd := r.read()
+	if rd == r.write() {
+		return false
+	}
+	copy(dst, r.slot(rd))
+	binary.LittleEndian.PutUint64(r.m[offRead:], rd+1)
+	return true
+}
+
+// Commit the mapped contents to stable storage.
+func (r *Ring) Sync() error {
+	return msync(r.f, r.m)
+}
+
+// Unmap and close the file. The ring must not be used afterwards.
+func (r *Ring) Close() error {
+	err := munmap(r.m)
+	r.m = nil
+	if cerr := r.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,154 @@
+package mmapring_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer/mmapring"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "ring")
+
+	_, err := mmapring.Open(path, 0, 4)
+	assert.Error(err)
+
+	r, err := mmapring.Open(path, 4, 3)
+	assert.NoError(err)
+	assert.Equal(3, r.Cap())
+	assert.Equal(4, r.SlotSize())
+
+	dst := make([]byte, 4)
+	for i := 0; i < 5; i++ {
+		assert.Equal(true, r.Push([]byte("ab")))
+		assert.Equal(true, r.Push([]byte("cdef")))
+		assert.Equal(true, r.Push(nil))
+		assert.Equal(false, r.Push([]byte("x")))
+		assert.Equal(3, r.Len())
+		assert.Equal(true, r.Pop(dst))
+		assert.Equal("ab\x00\x00", string(dst))
+		assert.Equal(true, r.Pop(dst))
+		assert.Equal("cdef", string(dst))
+		assert.Equal(true, r.Pop(dst))
+		assert.Equal("\x00\x00\x00\x00", string(dst))
+		assert.Equal(false, r.Pop(dst))
+	}
+	assert.Panics(func() { r.Push([]byte("12345")) })
+	assert.Panics(func() { r.Pop(dst[:3]) })
+
+	// contents survive reopening
+	r.Push([]byte("keep"))
+	r.Push([]byte("me"))
+	assert.NoError(r.Close())
+	r, err = mmapring.Open(path, 4, 3)
+	assert.NoError(err)
+	assert.Equal(2, r.Len())
+	assert.Equal(0, r.Discarded())
+	r.Pop(dst)
+	assert.Equal("keep", string(dst))
+	assert.NoError(r.Close())
+
+	// geometry must match
+	_, err = mmapring.Open(path, 4, 4)
+	assert.ErrorIs(err, mmapring.ErrMismatch)
+	_, err = mmapring.Open(path, 2, 6)
+	assert.ErrorIs(err, mmapring.ErrMismatch)
+
+	other := filepath.Join(t.TempDir(), "other")
+	assert.NoError(os.WriteFile(other, make([]byte, 64+24), 0o644))
+	_, err = mmapring.Open(other, 4, 3)
+	assert.ErrorIs(err, mmapring.ErrMismatch)
+}
+
+func TestRecovery(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "ring")
+
+	r, err := mmapring.Open(path, 4, 4)
+	assert.NoError(err)
+	r.Push([]byte("one"))
+	r.Push([]byte("two"))
+	r.Push([]byte("six"))
+	assert.NoError(r.Close())
+
+	// the last slot didn't reach the disk: slots follow the header, 4 bytes of contents and 4 of checksum each
+	data, err := os.ReadFile(path)
+	assert.NoError(err)
+	data[64+2*8] = 0
+	assert.NoError(os.WriteFile(path, data, 0o644))
+	r, err = mmapring.Open(path, 4, 4)
+	assert.NoError(err)
+	assert.Equal(1, r.Discarded())
+	assert.Equal(2, r.Len())
+	dst := make([]byte, 4)
+	r.Pop(dst)
+	assert.Equal("one\x00", string(dst))
+	assert.Equal(true, r.Push([]byte("ten")))
+	assert.NoError(r.Close())
+
+	r, err = mmapring.Open(path, 4, 4)
+	assert.NoError(err)
+	assert.Equal(0, r.Discarded())
+	assert.Equal(2, r.Len())
+	assert.NoError(r.Close())
+
+	// damaged geometry
+	data, err = os.ReadFile(path)
+	assert.NoError(err)
+	data[16] ^= 0xff
+	assert.NoError(os.WriteFile(path, data, 0o644))
+	_, err = mmapring.Open(path, 4, 4)
+	assert.Error(err)
+}
+
+func TestRecoveryPreviousLap(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "ring")
+
+	r, err := mmapring.Open(path, 4, 2)
+	assert.NoError(err)
+	r.Push([]byte("aaaa"))
+	r.Push([]byte("bbbb"))
+	assert.NoError(r.Sync())
+	old, err := os.ReadFile(path)
+	assert.NoError(err)
+	dst := make([]byte, 4)
+	r.Pop(dst)
+	r.Pop(dst)
+	r.Push([]byte("cccc"))
+	assert.NoError(r.Sync())
+	assert.NoError(r.Close())
+
+	// the write position of the push reached the disk, the slot kept valid contents from the previous lap
+	data, err := os.ReadFile(path)
+	assert.NoError(err)
+	copy(data[64:64+8], old[64:64+8])
+	assert.NoError(os.WriteFile(path, data, 0o644))
+	r, err = mmapring.Open(path, 4, 2)
+	assert.NoError(err)
+	assert.Equal(1, r.Discarded())
+	assert.Equal(0, r.Len())
+	assert.Equal(false, r.Pop(dst))
+	assert.NoError(r.Close())
+}
+
+func Example() {
+	dir, _ := os.MkdirTemp("", "mmapring")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "telemetry")
+
+	r, _ := mmapring.Open(path, 8, 16)
+	r.Push([]byte("cpu=42"))
+	r.Close()
+
+	// after a restart
+	r, _ = mmapring.Open(path, 8, 16)
+	defer r.Close()
+	dst := make([]byte, r.SlotSize())
+	r.Pop(dst)
+	fmt.Printf("%s %d\n", dst[:6], r.Len())
+	// Output: cpu=42 0
+}
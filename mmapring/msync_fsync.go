@@ -0,0 +1,12 @@
+//go:build unix && !(linux || darwin || dragonfly || freebsd || openbsd)
+
+package mmapring
+
+import (
+	"os"
+)
+
+// The syscall package has no msync here, mapped pages share the page cache and are written by fsync.
+func msync(f *os.File, m []byte) error {
+	return f.Sync()
+}
@@ -0,0 +1,17 @@
+//go:build linux || darwin || dragonfly || freebsd || openbsd
+
+package mmapring
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func msync(f *os.File, m []byte) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_MSYNC, uintptr(unsafe.Pointer(&m[0])), uintptr(len(m)), syscall.MS_SYNC)
+	if errno != 0 {
+		return os.NewSyscallError("msync", errno)
+	}
+	return nil
+}
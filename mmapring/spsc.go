@@ -0,0 +1,175 @@
+package mmapring
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Layout of SPSC files, all integers little endian:
+//
+//	offset  size  field
+//	0       4     magic "RBSP"
+//	4       4     format version
+//	8       8     slot size in bytes
+//	16      8     number of slots
+//	64      8     write position, counts slots pushed so far, stored atomically by the producer
+//	128     8     read position, counts slots popped so far, stored atomically by the consumer
+//	192           slots
+const (
+	spscMagic      = "RBSP"
+	spscVersion    = 1
+	spscHeaderSize = 192
+	spscOffWrite   = 64
+	spscOffRead    = 128
+)
+
+// Lock-free ring buffer of fixed size slots in a file mapping shared between two processes, one producer and one
+// consumer.
+//
+// The producer copies an element into its slot and then publishes it by atomically storing the write position, the
+// consumer copies the element out and then releases the slot by atomically storing the read position. Positions sit
+// on separate cache lines. For POSIX shared memory semantics place the file on a tmpfs like /dev/shm.
+type SPSC struct {
+	f        *os.File
+	m        []byte
+	write    *atomic.Uint64
+	read     *atomic.Uint64
+	slotSize int
+	slots    int
+}
+
+// Create a new empty shared ring file with the given number of slots of slotSize bytes each, replacing an existing
+// file. Typically called by one side, the other side then uses OpenSPSC.
+func CreateSPSC(path string, slotSize, slots int) (*SPSC, error) {
+	if slotSize < 1 || slots < 1 {
+		return nil, fmt.Errorf("mmapring: invalid geometry %d x %d", slots, slotSize)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	size := spscHeaderSize + slotSize*slots
+	if err := f.Truncate(int64(size)); err != nil {
+		f.Close()
+		return nil, err
+	}
+	q, err := mapSPSC(f, size, slotSize, slots)
+	if err != nil {
+		return nil, err
+	}
+	binary.LittleEndian.PutUint32(q.m[offVersion:], spscVersion)
+	binary.LittleEndian.PutUint64(q.m[offSlotSize:], uint64(slotSize))
+	binary.LittleEndian.PutUint64(q.m[offSlots:], uint64(slots))
+	copy(q.m, spscMagic) // written last, marks the header complete
+	return q, nil
+}
+
+// Open a shared ring file created by CreateSPSC, the geometry is read from its header.
+func OpenSPSC(path string) (*SPSC, error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	var hdr [offSlots + 8]byte
+	if _, err := f.ReadAt(hdr[:], 0); err != nil || string(hdr[:len(spscMagic)]) != spscMagic {
+		f.Close()
+		return nil, ErrMismatch
+	}
+	if v := binary.LittleEndian.Uint32(hdr[offVersion:]); v != spscVersion {
+		f.Close()
+		return nil, fmt.Errorf("mmapring: unsupported format version %d", v)
+	}
+	slotSize := int(binary.LittleEndian.Uint64(hdr[offSlotSize:]))
+	slots := int(binary.LittleEndian.Uint64(hdr[offSlots:]))
+	size := spscHeaderSize + slotSize*slots
+	if fi, err := f.Stat(); err != nil || slotSize < 1 || slots < 1 || fi.Size() != int64(size) {
+		f.Close()
+		return nil, ErrMismatch
+	}
+	return mapSPSC(f, size, slotSize, slots)
+}
+
+func mapSPSC(f *os.File, size, slotSize, slots int) (*SPSC, error) {
+	m, err := mmap(f, size)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &SPSC{
+		f:        f,
+		m:        m,
+		write:    (*atomic.Uint64)(unsafe.Pointer(&m[spscOffWrite])),
+		read:     (*atomic.Uint64)(unsafe.Pointer(&m[spscOffRead])),
+		slotSize: slotSize,
+		slots:    slots,
+	}, nil
+}
+
+func (q *SPSC) slot(pos uint64) []byte {
+	off := spscHeaderSize + int(pos%uint64(q.slots))*q.slotSize
+	return q.m[off : off+q.slotSize]
+}
+
+// How many slots a buffer can store?
+func (q *SPSC) Cap() int {
+	return q.slots
+}
+
+// How many slots are currently stored in the buffer? The value may be stale by the time it is returned.
+func (q *SPSC) Len() int {
+	r := q.read.Load()
+	return int(q.write.Load() - r)
+}
+
+// Size of a slot in bytes.
+func (q *SPSC) SlotSize() int {
+	return q.slotSize
+}
+
+// Push a new element to the buffer, p is stored in a slot padded with zeroes. Must only be called by the producer.
+// Panics if p is longer than a slot.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (q *SPSC) Push(p []byte) bool {
+	if len(p) > q.slotSize {
+		panic("mmapring: element larger than slot")
+	}
+	w := q.write.Load()
+	if w-q.read.Load() == uint64(q.slots) {
+		return false // no more space
+	}
+	s := q.slot(w)
+	clear(s[copy(s, p):])
+	q.write.Store(w + 1)
+	return true
+}
+
+// Try to pop an element from the buffer, copying the slot into dst. Must only be called by the consumer. Panics if
+// dst is shorter than a slot.
+//
+// Returns true on success. Returns false if there were no elements in the buffer.
+func (q *SPSC) Pop(dst []byte) bool {
+	if len(dst) < q.slotSize {
+		panic("mmapring: destination shorter than slot")
+	}
+	r := q.read.Load()
+	if r == q.write.Load() {
+		return false
+	}
+	copy(dst, q.slot(r))
+	q.read.Store(r + 1)
+	return true
+}
+
+// Unmap and close the file. The ring must not be used afterwards.
+func (q *SPSC) Close() error {
+	err := munmap(q.m)
+	q.m = nil
+	if cerr := q.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
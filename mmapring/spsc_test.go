@@ -0,0 +1,87 @@
+package mmapring_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/nsf/ringbuffer/mmapring"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestSPSC(t *testing.T) {
+	assert := assert.New(t)
+	path := filepath.Join(t.TempDir(), "shm")
+
+	_, err := mmapring.OpenSPSC(path)
+	assert.Error(err)
+	_, err = mmapring.CreateSPSC(path, 8, 0)
+	assert.Error(err)
+
+	producer, err := mmapring.CreateSPSC(path, 8, 4)
+	assert.NoError(err)
+	defer producer.Close()
+	// separate mappings of the same file, as in two processes
+	consumer, err := mmapring.OpenSPSC(path)
+	assert.NoError(err)
+	defer consumer.Close()
+	assert.Equal(4, consumer.Cap())
+	assert.Equal(8, consumer.SlotSize())
+
+	const n = 10000
+	go func() {
+		var p [8]byte
+		for i := uint64(0); i < n; {
+			binary.LittleEndian.PutUint64(p[:], i)
+			if producer.Push(p[:]) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+	dst := make([]byte, 8)
+	for i := uint64(0); i < n; {
+		if !consumer.Pop(dst) {
+			runtime.Gosched()
+			continue
+		}
+		if v := binary.LittleEndian.Uint64(dst); v != i {
+			assert.Equal(i, v)
+			return
+		}
+		i++
+	}
+	assert.Equal(0, consumer.Len())
+
+	assert.Equal(true, producer.Push([]byte("x")))
+	assert.Equal(1, consumer.Len())
+	assert.Panics(func() { producer.Push(make([]byte, 9)) })
+	assert.Panics(func() { consumer.Pop(dst[:7]) })
+
+	other := filepath.Join(t.TempDir(), "other")
+	assert.NoError(os.WriteFile(other, []byte("RBMM"), 0o600))
+	_, err = mmapring.OpenSPSC(other)
+	assert.ErrorIs(err, mmapring.ErrMismatch)
+}
+
+func ExampleCreateSPSC() {
+	dir, _ := os.MkdirTemp("", "shm")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events")
+
+	// producer process
+	tx, _ := mmapring.CreateSPSC(path, 16, 64)
+	defer tx.Close()
+	tx.Push([]byte("order created"))
+
+	// consumer process
+	rx, _ := mmapring.OpenSPSC(path)
+	defer rx.Close()
+	dst := make([]byte, rx.SlotSize())
+	rx.Pop(dst)
+	fmt.Printf("%s\n", dst[:13])
+	// Output: order created
+}
@@ -0,0 +1,98 @@
+package ringbuffer
+
+import (
+	"sync/atomic"
+)
+
+type mpmcSlot[T any] struct {
+	seq atomic.Uint64
+	v   T
+}
+
+// Bounded lock-free FIFO queue for any number of producer and consumer goroutines.
+//
+// Implementation detail: this is Dmitry Vyukov's bounded MPMC queue. Every slot carries a sequence number telling
+// whether it is free for the push at a given position or holds the element for the pop at that position. Producers
+// and consumers claim positions with a compare-and-swap on their own counter and then only touch the claimed slot.
+type MPMC[T any] struct {
+	_       [cacheLine]byte
+	enqueue atomic.Uint64
+	_       [cacheLine - 8]byte
+	dequeue atomic.Uint64
+	_       [cacheLine - 8]byte
+	slots   []mpmcSlot[T]
+}
+
+// Create a new queue which can store capacity elements.
+func NewMPMC[T any](capacity int) *MPMC[T] {
+	q := &MPMC[T]{slots: make([]mpmcSlot[T], max(capacity, 0))}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// How many elements a queue can store?
+func (q *MPMC[T]) Cap() int {
+	return len(q.slots)
+}
+
+// How many elements are currently stored in the queue? The value is approximate while the queue is in use.
+func (q *MPMC[T]) Len() int {
+	d := q.dequeue.Load()
+	e := q.enqueue.Load()
+	if e < d {
+		return 0
+	}
+	return min(int(e-d), len(q.slots))
+}
+
+// Try to push a new element to the queue.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (q *MPMC[T]) TryPush(v T) bool {
+	if len(q.slots) == 0 {
+		return false
+	}
+	pos := q.enqueue.Load()
+	for {
+		slot := &q.slots[pos%uint64(len(q.slots))]
+		diff := int64(slot.seq.Load() - pos)
+		if diff == 0 {
+			if q.enqueue.CompareAndSwap(pos, pos+1) {
+				slot.v = v
+				slot.seq.Store(pos + 1)
+				return true
+			}
+		} else if diff < 0 {
+			return false // slot still holds the element of the previous lap
+		}
+		pos = q.enqueue.Load()
+	}
+}
+
+// Try to pop an element from the queue.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the queue.
+func (q *MPMC[T]) TryPop() (T, bool) {
+	var def T
+	if len(q.slots) == 0 {
+		return def, false
+	}
+	pos := q.dequeue.Load()
+	for {
+		slot := &q.slots[pos%uint64(len(q.slots))]
+		diff := int64(slot.seq.Load() - (pos + 1))
+		if diff == 0 {
+			if q.dequeue.CompareAndSwap(pos, pos+1) {
+				v := slot.v
+				slot.v = def
+				slot.seq.Store(pos + uint64(len(q.slots)))
+				return v, true
+			}
+		} else if diff < 0 {
+			return def, false // slot not written yet
+		}
+		pos = q.dequeue.Load()
+	}
+}
@@ -0,0 +1,103 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMPMC(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		q := ringbuffer.NewMPMC[int](0)
+		assert.Equal(false, q.TryPush(1))
+		_, ok := q.TryPop()
+		assert.Equal(false, ok)
+	}
+
+	q := ringbuffer.NewMPMC[int](3)
+	assert.Equal(3, q.Cap())
+	for i := 0; i < 5; i++ {
+		assert.Equal(true, q.TryPush(1))
+		assert.Equal(true, q.TryPush(2))
+		assert.Equal(true, q.TryPush(3))
+		assert.Equal(false, q.TryPush(4))
+		assert.Equal(3, q.Len())
+		for j := 1; j <= 3; j++ {
+			v, ok := q.TryPop()
+			assert.Equal(true, ok)
+			assert.Equal(j, v)
+		}
+		_, ok := q.TryPop()
+		assert.Equal(false, ok)
+		assert.Equal(0, q.Len())
+	}
+}
+
+func TestMPMCConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	const workers = 4
+	const perWorker = 10000
+	q := ringbuffer.NewMPMC[int](16)
+
+	var popped atomic.Int64
+	var sum atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= perWorker; {
+				if q.TryPush(i) {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for popped.Load() < workers*perWorker {
+				if v, ok := q.TryPop(); ok {
+					sum.Add(int64(v))
+					popped.Add(1)
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(int64(workers*perWorker), popped.Load())
+	assert.Equal(int64(workers*perWorker*(perWorker+1)/2), sum.Load())
+	assert.Equal(0, q.Len())
+}
+
+func ExampleMPMC() {
+	q := ringbuffer.NewMPMC[int](8)
+	var wg sync.WaitGroup
+	for i := 1; i <= 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.TryPush(i)
+		}()
+	}
+	wg.Wait()
+	sum := 0
+	for {
+		v, ok := q.TryPop()
+		if !ok {
+			break
+		}
+		sum += v
+	}
+	fmt.Println(sum)
+	// Output: 10
+}
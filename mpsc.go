@@ -0,0 +1,118 @@
+package ringbuffer
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// Bounded FIFO queue for any number of producer goroutines and a single consumer goroutine.
+//
+// Producers claim positions with a single fetch-and-add and publish elements through per-slot sequence numbers, the
+// same way as MPMC. As the claim can't be taken back, Push waits for the consumer when the queue is full. The
+// consumer owns the read position and pops published elements in batches without atomic read-modify-write operations.
+type MPSC[T any] struct {
+	_       [cacheLine]byte
+	enqueue atomic.Uint64
+	_       [cacheLine - 8]byte
+	read    atomic.Uint64 // written by the consumer only
+	_       [cacheLine - 8]byte
+	slots   []mpmcSlot[T]
+}
+
+// Create a new queue which can store capacity elements.
+func NewMPSC[T any](capacity int) *MPSC[T] {
+	q := &MPSC[T]{slots: make([]mpmcSlot[T], max(capacity, 0))}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// How many elements a queue can store?
+func (q *MPSC[T]) Cap() int {
+	return len(q.slots)
+}
+
+// How many elements are currently stored in the queue? The value is approximate while the queue is in use, it
+// includes elements of pushes still waiting for free space.
+func (q *MPSC[T]) Len() int {
+	r := q.read.Load()
+	return int(q.enqueue.Load() - r)
+}
+
+// Push a new element to the queue, waiting for free space if the queue is full.
+//
+// Returns false without waiting if the queue has zero capacity.
+func (q *MPSC[T]) Push(v T) bool {
+	if len(q.slots) == 0 {
+		return false
+	}
+	pos := q.enqueue.Add(1) - 1
+	slot := &q.slots[pos%uint64(len(q.slots))]
+	for slot.seq.Load() != pos {
+		runtime.Gosched() // consumer hasn't freed the slot from the previous lap yet
+	}
+	slot.v = v
+	slot.seq.Store(pos + 1)
+	return true
+}
+
+// Try to push a new element to the queue without waiting.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (q *MPSC[T]) TryPush(v T) bool {
+	if len(q.slots) == 0 {
+		return false
+	}
+	pos := q.enqueue.Load()
+	for {
+		slot := &q.slots[pos%uint64(len(q.slots))]
+		diff := int64(slot.seq.Load() - pos)
+		if diff == 0 {
+			if q.enqueue.CompareAndSwap(pos, pos+1) {
+				slot.v = v
+				slot.seq.Store(pos + 1)
+				return true
+			}
+		} else if diff < 0 {
+			return false // slot still holds the element of the previous lap
+		}
+		pos = q.enqueue.Load()
+	}
+}
+
+// Try to pop an element from the queue. Must only be called from the consumer goroutine.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no published elements
+// in the queue.
+func (q *MPSC[T]) Pop() (T, bool) {
+	var v [1]T
+	if q.PopMany(v[:]) == 0 {
+		return v[0], false
+	}
+	return v[0], true
+}
+
+// Pop up to len(dst) published elements from the queue into dst, in FIFO order. Must only be called from the
+// consumer goroutine.
+//
+// Returns the number of elements popped. Stops early at an element whose push is not yet complete.
+func (q *MPSC[T]) PopMany(dst []T) int {
+	if len(q.slots) == 0 {
+		return 0
+	}
+	var def T
+	pos := q.read.Load()
+	n := 0
+	for ; n < len(dst); n++ {
+		slot := &q.slots[(pos+uint64(n))%uint64(len(q.slots))]
+		if slot.seq.Load() != pos+uint64(n)+1 {
+			break
+		}
+		dst[n] = slot.v
+		slot.v = def
+		slot.seq.Store(pos + uint64(n) + uint64(len(q.slots)))
+	}
+	q.read.Store(pos + uint64(n))
+	return n
+}
@@ -0,0 +1,124 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestMPSC(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		q := ringbuffer.NewMPSC[int](0)
+		assert.Equal(false, q.Push(1))
+		assert.Equal(false, q.TryPush(1))
+		_, ok := q.Pop()
+		assert.Equal(false, ok)
+	}
+
+	q := ringbuffer.NewMPSC[int](3)
+	assert.Equal(3, q.Cap())
+	dst := make([]int, 2)
+	for i := 0; i < 5; i++ {
+		assert.Equal(true, q.Push(1))
+		assert.Equal(true, q.TryPush(2))
+		assert.Equal(true, q.Push(3))
+		assert.Equal(false, q.TryPush(4))
+		assert.Equal(3, q.Len())
+		v, ok := q.Pop()
+		assert.Equal(true, ok)
+		assert.Equal(1, v)
+		assert.Equal(2, q.PopMany(dst))
+		assert.Equal([]int{2, 3}, dst)
+		assert.Equal(0, q.PopMany(dst))
+		assert.Equal(0, q.Len())
+	}
+}
+
+func TestMPSCConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	const producers = 4
+	const perProducer = 10000
+	q := ringbuffer.NewMPSC[int](16)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				// each producer's elements arrive in order
+				q.Push(p*perProducer + i)
+			}
+		}()
+	}
+
+	last := make([]int, producers)
+	for p := range last {
+		last[p] = -1
+	}
+	dst := make([]int, 8)
+	for got := 0; got < producers*perProducer; {
+		n := q.PopMany(dst)
+		if n == 0 {
+			runtime.Gosched()
+		}
+		for _, v := range dst[:n] {
+			p, i := v/perProducer, v%perProducer
+			assert.Equal(last[p]+1, i)
+			last[p] = i
+		}
+		got += n
+	}
+	wg.Wait()
+	assert.Equal(0, q.Len())
+}
+
+func TestMPSCTryPushContended(t *testing.T) {
+	assert := assert.New(t)
+
+	// there is always free space, contention alone must not make TryPush fail
+	const producers = 4
+	const perProducer = 10000
+	q := ringbuffer.NewMPSC[int](producers * perProducer)
+	var wg sync.WaitGroup
+	failed := make([]int, producers)
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				if !q.TryPush(i) {
+					failed[p]++
+				}
+				if i%100 == 0 {
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(make([]int, producers), failed)
+	assert.Equal(producers*perProducer, q.Len())
+}
+
+func ExampleMPSC() {
+	q := ringbuffer.NewMPSC[string](8)
+	var wg sync.WaitGroup
+	for _, src := range []string{"db", "http", "cache"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.Push(src + " ok")
+		}()
+	}
+	wg.Wait()
+	batch := make([]string, 8)
+	fmt.Println(q.PopMany(batch))
+	// Output: 3
+}
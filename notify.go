@@ -0,0 +1,97 @@
+package ringbuffer
+
+// Lazily created state transition signals of a concurrent buffer. The owner's mutex protects it.
+type notifier struct {
+	readable chan struct{}
+	writable chan struct{}
+	watchers []chan struct{} // private readable signals of waiters on several buffers, see Select
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func (n *notifier) readableChan(ready bool) chan struct{} {
+	if n.readable == nil {
+		n.readable = make(chan struct{}, 1)
+		if ready {
+			signal(n.readable)
+		}
+	}
+	return n.readable
+}
+
+func (n *notifier) writableChan(ready bool) chan struct{} {
+	if n.writable == nil {
+		n.writable = make(chan struct{}, 1)
+		if ready {
+			signal(n.writable)
+		}
+	}
+	return n.writable
+}
+
+func (n *notifier) watch(ch chan struct{}) {
+	n.watchers = append(n.watchers, ch)
+}
+
+func (n *notifier) unwatch(ch chan struct{}) {
+	for i, w := range n.watchers {
+		if w == ch {
+			n.watchers = append(n.watchers[:i], n.watchers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Fire signals for transitions between the buffer state before and after an operation.
+func (n *notifier) update(wasEmpty, wasFull, isEmpty, isFull bool) {
+	if wasEmpty && !isEmpty {
+		if n.readable != nil {
+			signal(n.readable)
+		}
+		for _, ch := range n.watchers {
+			signal(ch)
+		}
+	}
+	if n.writable != nil && wasFull && !isFull {
+		signal(n.writable)
+	}
+}
+
+// Get a channel receiving a value when the buffer goes from empty to non-empty.
+//
+// Signals are coalesced, a receive means there may be elements to pop, not how many. If the buffer is not empty when
+// the channel is first requested, a signal is already pending.
+func (b *Sync[T]) Readable() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.notify.readableChan(!b.buf.IsEmpty())
+}
+
+// Get a channel receiving a value when the buffer goes from full to non-full.
+//
+// Signals are coalesced, a receive means there may be free space, not how much. If the buffer is not full when the
+// channel is first requested, a signal is already pending.
+func (b *Sync[T]) Writable() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.notify.writableChan(!b.buf.IsFull())
+}
+
+// Get a channel receiving a value when the buffer goes from empty to non-empty, or gets closed. See Sync.Readable.
+func (b *Blocking[T]) Readable() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.notify.readableChan(b.closed || !b.buf.IsEmpty())
+}
+
+// Get a channel receiving a value when the buffer goes from full to non-full, or gets closed. See Sync.Writable.
+func (b *Blocking[T]) Writable() <-chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.notify.writableChan(b.closed || !b.buf.IsFull())
+}
@@ -0,0 +1,95 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func fired(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestSyncReadableWritable(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewSync[int](2)
+	r := b.Readable()
+	w := b.Writable()
+	assert.Equal(false, fired(r))
+	assert.Equal(true, fired(w))
+
+	b.Push(1)
+	assert.Equal(true, fired(r))
+	b.Push(2)
+	assert.Equal(false, fired(r))
+	assert.Equal(false, fired(w))
+
+	b.Pop()
+	assert.Equal(true, fired(w))
+	b.Pop()
+	assert.Equal(false, fired(w))
+
+	// batch operations and resize
+	b.PushMany([]int{1, 2})
+	assert.Equal(true, fired(r))
+	b.Resize(3)
+	assert.Equal(true, fired(w))
+	b.Push(3)
+	b.Drain()
+	assert.Equal(true, fired(w))
+	assert.Equal(false, fired(r))
+
+	// a pending signal is coalesced
+	b.Push(1)
+	b.Pop()
+	b.Push(1)
+	assert.Equal(true, fired(r))
+	assert.Equal(false, fired(r))
+
+	// signal is pending for a channel requested when the buffer is not empty
+	assert.Equal(true, fired(ringbuffer.NewSync[int](1).Writable()))
+	b = ringbuffer.NewSync[int](1)
+	b.Push(1)
+	assert.Equal(true, fired(b.Readable()))
+	assert.Equal(false, fired(b.Writable()))
+}
+
+func TestBlockingReadableWritable(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewBlocking[int](1)
+	r := b.Readable()
+	w := b.Writable()
+	assert.Equal(false, fired(r))
+	assert.Equal(true, fired(w))
+
+	b.Push(1)
+	assert.Equal(true, fired(r))
+	assert.Equal(false, fired(w))
+	b.Pop()
+	assert.Equal(true, fired(w))
+	b.TryPush(1)
+	assert.Equal(true, fired(r))
+	b.TryPop()
+	assert.Equal(true, fired(w))
+
+	b.Close()
+	assert.Equal(true, fired(r))
+	assert.Equal(true, fired(w))
+}
+
+func ExampleSync_Readable() {
+	b := ringbuffer.NewSync[string](4)
+	go b.Push("job")
+	<-b.Readable()
+	v, _ := b.Pop()
+	fmt.Println(v)
+	// Output: job
+}
@@ -0,0 +1,38 @@
+package ringbuffer
+
+// What Push does when there is no free space.
+type OverflowPolicy int
+
+const (
+	// Push fails and returns false, the buffer is left unchanged. This is the default.
+	Reject OverflowPolicy = iota
+	// The oldest element is evicted to make room, push succeeds.
+	DropOldest
+	// The newest stored element is replaced with the pushed one, push succeeds.
+	DropNewest
+)
+
+type options struct {
+	policy OverflowPolicy
+}
+
+// Optional setting for NewWithOptions.
+type Option func(*options)
+
+// Set the overflow policy, which applies to every push path not having its own explicit overflow behaviour.
+func WithOverflowPolicy(p OverflowPolicy) Option {
+	return func(o *options) {
+		o.policy = p
+	}
+}
+
+// Create a new buffer which can store capacity elements, configured by options. See New.
+func NewWithOptions[T any](capacity int, opts ...Option) RingBuffer[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	b := New[T](capacity)
+	b.policy = o.policy
+	return b
+}
@@ -0,0 +1,62 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	push := func(b *ringbuffer.RingBuffer[int], vs ...int) (res []bool) {
+		for _, v := range vs {
+			res = append(res, b.Push(v))
+		}
+		return res
+	}
+
+	{
+		b := ringbuffer.NewWithOptions[int](3)
+		assert.Equal([]bool{true, true, true, false}, push(&b, 1, 2, 3, 4))
+		assert.Equal([]int{1, 2, 3}, contents(&b))
+	}
+	{
+		b := ringbuffer.NewWithOptions[int](3, ringbuffer.WithOverflowPolicy(ringbuffer.Reject))
+		assert.Equal([]bool{true, true, true, false}, push(&b, 1, 2, 3, 4))
+		assert.Equal([]int{1, 2, 3}, contents(&b))
+	}
+	{
+		b := ringbuffer.NewWithOptions[int](3, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest))
+		assert.Equal([]bool{true, true, true, true, true}, push(&b, 1, 2, 3, 4, 5))
+		assert.Equal([]int{3, 4, 5}, contents(&b))
+
+		// policy survives resizing
+		b.Pop()
+		assert.Equal(true, b.Resize(2))
+		push(&b, 6)
+		assert.Equal([]int{5, 6}, contents(&b))
+	}
+	{
+		b := ringbuffer.NewWithOptions[int](3, ringbuffer.WithOverflowPolicy(ringbuffer.DropNewest))
+		assert.Equal([]bool{true, true, true, true, true}, push(&b, 1, 2, 3, 4, 5))
+		assert.Equal([]int{1, 2, 5}, contents(&b))
+	}
+	{
+		b := ringbuffer.NewWithOptions[int](0, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest))
+		assert.Equal([]bool{false}, push(&b, 1))
+		b = ringbuffer.NewWithOptions[int](0, ringbuffer.WithOverflowPolicy(ringbuffer.DropNewest))
+		assert.Equal([]bool{false}, push(&b, 1))
+	}
+}
+
+func ExampleNewWithOptions() {
+	b := ringbuffer.NewWithOptions[int](3, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest))
+	for i := 1; i <= 5; i++ {
+		b.Push(i)
+	}
+	x, y := b.Regions()
+	fmt.Println(x, y)
+	// Output: [3 4] [5]
+}
@@ -0,0 +1,85 @@
+package ringbuffer
+
+import "slices"
+
+// What is the sequence number of the oldest element?
+//
+// Elements are numbered in push order, the element at logical index i has sequence number Seq()+i. Popping an element
+// advances it, operations which renumber elements bump an internal generation instead, see ReadCursor.
+func (b RingBuffer[T]) Seq() uint64 {
+	return b.seq
+}
+
+// Protect the element with sequence number seq from eviction, e.g. to keep interesting events in a flight recorder.
+//
+// Overwriting pushes (PushOverwrite, the DropOldest policy) evict the oldest element which is not pinned instead of the
+// oldest one. This renumbers the newer elements, pins follow the elements they protect. When every stored element is
+// pinned, overwriting pushes fail. Pinned elements can still be popped. Pins are dropped by other operations which
+// renumber elements, the same ones which invalidate read cursors.
+//
+// Returns true on success. Returns false if there is no element with that sequence number.
+func (b *RingBuffer[T]) Pin(seq uint64) bool {
+	b.prunePins()
+	if seq < b.seq || seq-b.seq >= uint64(b.Len()) {
+		return false
+	}
+	if i, found := slices.BinarySearch(b.pins, seq); !found {
+		b.pins = slices.Insert(b.pins, i, seq)
+	}
+	return true
+}
+
+// Remove the protection added by Pin. Returns false if the element with sequence number seq was not pinned.
+func (b *RingBuffer[T]) Unpin(seq uint64) bool {
+	b.prunePins()
+	i, found := slices.BinarySearch(b.pins, seq)
+	if found {
+		b.pins = slices.Delete(b.pins, i, i+1)
+	}
+	return found
+}
+
+// How many stored elements are pinned?
+func (b *RingBuffer[T]) Pinned() int {
+	b.prunePins()
+	return len(b.pins)
+}
+
+// Is there room for an overwriting push, either free space or an element which is not pinned?
+func (b *RingBuffer[T]) canOverwrite() bool {
+	return b.Cap() > 0 && (!b.IsFull() || b.Pinned() < b.Len())
+}
+
+// Forget pins of popped elements, or all pins if elements were renumbered since they were added.
+func (b *RingBuffer[T]) prunePins() {
+	if b.pinGen != b.gen {
+		b.pins, b.pinGen = b.pins[:0], b.gen
+	}
+	i, _ := slices.BinarySearch(b.pins, b.seq)
+	b.pins = slices.Delete(b.pins, 0, i)
+}
+
+// Push v into a full buffer by evicting the oldest element which is not pinned.
+//
+// Returns the evicted element and true on success. Returns v and false if every element is pinned.
+func (b *RingBuffer[T]) overwritePinned(v T) (T, bool) {
+	b.prunePins()
+	i := 0
+	for i < len(b.pins) && b.pins[i] == b.seq+uint64(i) {
+		i++
+	}
+	if i == b.Len() {
+		return v, false
+	}
+	ev := *b.slot(i)
+	b.remove(i)
+	if i > 0 {
+		// newer elements moved down by one, and so do their pins
+		for k := i; k < len(b.pins); k++ {
+			b.pins[k]--
+		}
+		b.pinGen = b.gen
+	}
+	Push(b.buffer, b.read, &b.write, v)
+	return ev, true
+}
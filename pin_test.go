@@ -0,0 +1,112 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPin(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewFrom(4, []int{1, 2, 3, 4})
+	b.Pop()
+	b.Push(5)
+	assert.Equal(uint64(1), b.Seq())
+
+	// only stored elements can be pinned
+	assert.Equal(false, b.Pin(0))
+	assert.Equal(false, b.Pin(5))
+	assert.Equal(true, b.Pin(1))
+	assert.Equal(true, b.Pin(1))
+	assert.Equal(1, b.Pinned())
+
+	// eviction skips the pinned oldest element
+	ev, ok := b.PushOverwrite(6)
+	assert.Equal(3, ev)
+	assert.Equal(true, ok)
+	assert.Equal([]int{2, 4, 5, 6}, contents(&b))
+
+	// pins follow their elements when newer ones are renumbered
+	assert.Equal(true, b.Pin(3)) // 5
+	ev, _ = b.PushOverwrite(7)
+	assert.Equal(4, ev)
+	ev, _ = b.PushOverwrite(8)
+	assert.Equal(6, ev)
+	assert.Equal([]int{2, 5, 7, 8}, contents(&b))
+
+	// unpinned, evicted as usual
+	assert.Equal(true, b.Unpin(2))
+	assert.Equal(false, b.Unpin(2))
+	ev, _ = b.PushOverwrite(9)
+	assert.Equal(5, ev)
+	assert.Equal([]int{2, 7, 8, 9}, contents(&b))
+
+	// every element pinned, nothing can be evicted
+	for seq := b.Seq(); seq < b.Seq()+4; seq++ {
+		b.Pin(seq)
+	}
+	ev, ok = b.PushOverwrite(10)
+	assert.Equal(10, ev)
+	assert.Equal(true, ok)
+	assert.Equal([]int{2, 7, 8, 9}, contents(&b))
+
+	// popping a pinned element unpins it
+	v, _ := b.Pop()
+	assert.Equal(2, v)
+	assert.Equal(3, b.Pinned())
+	b.Push(10)
+	ev, _ = b.PushOverwrite(11)
+	assert.Equal(10, ev)
+
+	// renumbering drops pins
+	b.Pop()
+	b.PushFront(0)
+	assert.Equal(0, b.Pinned())
+}
+
+func TestPinPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewWithOptions[int](3, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest))
+	b.PushMany([]int{1, 2, 3})
+	b.Pin(b.Seq())
+	assert.Equal(true, b.Push(4))
+	assert.Equal([]int{1, 3, 4}, contents(&b))
+	assert.Equal(3, b.PushMany([]int{5, 6, 7}))
+	assert.Equal([]int{1, 6, 7}, contents(&b))
+
+	b.Pin(b.Seq() + 1)
+	b.Pin(b.Seq() + 2)
+	assert.Equal(false, b.Push(8))
+	assert.Equal(0, b.PushMany([]int{8, 9}))
+	assert.Equal([]int{1, 6, 7}, contents(&b))
+
+	// pins survive resizing and cloning, clones are independent
+	b.Resize(4)
+	assert.Equal(3, b.Pinned())
+	c := b.Clone()
+	c.Unpin(c.Seq())
+	assert.Equal(3, b.Pinned())
+	assert.Equal(2, c.Pinned())
+
+	s := ringbuffer.NewSync[int](2)
+	s.PushMany([]int{1, 2})
+	assert.Equal(true, s.Pin(s.Seq()))
+	s.PushOverwrite(3)
+	assert.Equal([]int{1, 3}, s.Drain())
+	assert.Equal(0, s.Pinned())
+}
+
+func ExampleRingBuffer_Pin() {
+	recorder := ringbuffer.NewWithOptions[string](3, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest))
+	for _, event := range []string{"tick", "crash", "tick", "tick", "tick", "tick"} {
+		recorder.Push(event)
+		if event == "crash" {
+			recorder.Pin(recorder.Seq() + uint64(recorder.Len()-1))
+		}
+	}
+	fmt.Println(recorder.Drain())
+	// Output: [crash tick tick]
+}
@@ -0,0 +1,131 @@
+package ringbuffer
+
+import (
+	"io"
+	"sync"
+)
+
+type pipe struct {
+	mu       sync.Mutex
+	readable sync.Cond
+	writable sync.Cond
+	buf      Bytes
+	rerr     error // set when the reader is closed
+	werr     error // set when the writer is closed
+}
+
+// Read half of a pipe created by Pipe.
+type PipeReader struct {
+	p *pipe
+}
+
+// Write half of a pipe created by Pipe.
+type PipeWriter struct {
+	p *pipe
+}
+
+// Create a synchronous in-memory pipe buffering up to capacity bytes, at least one.
+//
+// Unlike io.Pipe, writes complete as soon as the data is buffered, a writer only waits when the buffer is full. All
+// memory is allocated here, reads and writes don't allocate. Both halves are safe for concurrent use.
+func Pipe(capacity int) (*PipeReader, *PipeWriter) {
+	p := &pipe{buf: Bytes{buf: New[byte](max(capacity, 1))}}
+	p.readable.L = &p.mu
+	p.writable.L = &p.mu
+	return &PipeReader{p}, &PipeWriter{p}
+}
+
+// Read buffered data, waiting for a writer if the buffer is empty.
+//
+// Once the buffer is empty and the write half is closed, returns the error it was closed with, io.EOF by default.
+// Returns io.ErrClosedPipe after the read half was closed.
+func (r *PipeReader) Read(data []byte) (int, error) {
+	p := r.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for {
+		if p.rerr != nil {
+			return 0, io.ErrClosedPipe
+		}
+		if p.buf.Len() > 0 || len(data) == 0 {
+			n, _ := p.buf.Read(data)
+			p.writable.Broadcast()
+			return n, nil
+		}
+		if p.werr != nil {
+			return 0, p.werr
+		}
+		p.readable.Wait()
+	}
+}
+
+// Close the read half. Subsequent writes return the given error, io.ErrClosedPipe if nil.
+func (r *PipeReader) CloseWithError(err error) error {
+	p := r.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err == nil {
+		err = io.ErrClosedPipe
+	}
+	if p.rerr == nil {
+		p.rerr = err
+	}
+	p.readable.Broadcast()
+	p.writable.Broadcast()
+	return nil
+}
+
+// Close the read half, see CloseWithError.
+func (r *PipeReader) Close() error {
+	return r.CloseWithError(nil)
+}
+
+// Write data to the buffer, waiting for a reader whenever the buffer is full.
+//
+// Returns the error the read half was closed with, after writing as much as was buffered before. Returns
+// io.ErrClosedPipe after the write half was closed.
+func (w *PipeWriter) Write(data []byte) (int, error) {
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	n := 0
+	for {
+		if p.werr != nil {
+			return n, io.ErrClosedPipe
+		}
+		if p.rerr != nil {
+			return n, p.rerr
+		}
+		if n == len(data) {
+			return n, nil
+		}
+		if p.buf.Free() > 0 {
+			m, _ := p.buf.Write(data[n:])
+			n += m
+			p.readable.Broadcast()
+			continue
+		}
+		p.writable.Wait()
+	}
+}
+
+// Close the write half. Once buffered data is read, subsequent reads return the given error, io.EOF if nil.
+func (w *PipeWriter) CloseWithError(err error) error {
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err == nil {
+		err = io.EOF
+	}
+	if p.werr == nil {
+		p.werr = err
+	}
+	p.readable.Broadcast()
+	p.writable.Broadcast()
+	return nil
+}
+
+// Close the write half, see CloseWithError.
+func (w *PipeWriter) Close() error {
+	return w.CloseWithError(nil)
+}
@@ -0,0 +1,89 @@
+package ringbuffer_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPipe(t *testing.T) {
+	assert := assert.New(t)
+
+	r, w := ringbuffer.Pipe(4)
+	data := strings.Repeat("0123456789", 100)
+	go func() {
+		n, err := w.Write([]byte(data))
+		assert.NoError(err)
+		assert.Equal(len(data), n)
+		w.Close()
+	}()
+	out, err := io.ReadAll(r)
+	assert.NoError(err)
+	assert.Equal(data, string(out))
+	_, err = w.Write([]byte("x"))
+	assert.ErrorIs(err, io.ErrClosedPipe)
+
+	// writes complete once buffered
+	r, w = ringbuffer.Pipe(8)
+	n, err := w.Write([]byte("abc"))
+	assert.NoError(err)
+	assert.Equal(3, n)
+	n, err = r.Read(nil)
+	assert.NoError(err)
+	assert.Equal(0, n)
+
+	// closing the writer with an error delivers buffered data first
+	boom := errors.New("boom")
+	w.CloseWithError(boom)
+	p := make([]byte, 8)
+	n, err = r.Read(p)
+	assert.NoError(err)
+	assert.Equal("abc", string(p[:n]))
+	_, err = r.Read(p)
+	assert.ErrorIs(err, boom)
+
+	// closing the reader fails pending and future writes
+	r, w = ringbuffer.Pipe(2)
+	w.Write([]byte("ab"))
+	done := make(chan error)
+	go func() {
+		n, err := w.Write([]byte("cd"))
+		assert.Equal(0, n)
+		done <- err
+	}()
+	r.CloseWithError(boom)
+	assert.ErrorIs(<-done, boom)
+	_, err = r.Read(p)
+	assert.ErrorIs(err, io.ErrClosedPipe)
+	r.Close()
+	_, err = w.Write([]byte("x"))
+	assert.ErrorIs(err, boom)
+}
+
+func TestPipeAllocs(t *testing.T) {
+	assert := assert.New(t)
+
+	r, w := ringbuffer.Pipe(64)
+	msg := []byte("hello")
+	p := make([]byte, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		w.Write(msg)
+		r.Read(p)
+	})
+	assert.Equal(0.0, allocs)
+}
+
+func ExamplePipe() {
+	r, w := ringbuffer.Pipe(16)
+	go func() {
+		fmt.Fprint(w, "some io.Reader stream to be read\n")
+		w.Close()
+	}()
+	io.Copy(os.Stdout, r)
+	// Output: some io.Reader stream to be read
+}
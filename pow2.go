@@ -0,0 +1,75 @@
+package ringbuffer
+
+import (
+	"math/bits"
+)
+
+// Fixed length FIFO ring buffer with power of two capacity, wrapping indices with a bit mask instead of a division.
+//
+// Read and write positions are free running counters, masked on every access. The whole backing slice is used, full
+// and empty states are told apart by the distance between the counters. The trade-off is memory: capacity is rounded
+// up to the next power of two, which can almost double the storage.
+type Pow2[T any] struct {
+	read   uint
+	write  uint
+	mask   uint
+	buffer []T
+}
+
+// Create a new buffer which can store at least capacity elements, rounded up to the next power of two.
+func NewPow2[T any](capacity int) Pow2[T] {
+	if capacity < 1 {
+		return Pow2[T]{}
+	}
+	n := uint(1) << bits.Len(uint(capacity-1))
+	return Pow2[T]{
+		mask:   n - 1,
+		buffer: make([]T, n),
+	}
+}
+
+// How many elements a buffer can store?
+func (b Pow2[T]) Cap() int {
+	return len(b.buffer)
+}
+
+// How many elements are currently stored in the buffer?
+func (b Pow2[T]) Len() int {
+	return int(b.write - b.read)
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *Pow2[T]) Push(v T) bool {
+	if b.write-b.read == uint(len(b.buffer)) {
+		return false // no more space
+	}
+	b.buffer[b.write&b.mask] = v
+	b.write++
+	return true
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *Pow2[T]) Pop() (T, bool) {
+	if b.read == b.write {
+		var def T
+		return def, false
+	}
+	v := b.buffer[b.read&b.mask]
+	b.read++
+	return v, true
+}
+
+// Look at the oldest element without removing it from the buffer.
+//
+// Returns the oldest element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b Pow2[T]) Peek() (T, bool) {
+	if b.read == b.write {
+		var def T
+		return def, false
+	}
+	return b.buffer[b.read&b.mask], true
+}
@@ -0,0 +1,54 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPow2(t *testing.T) {
+	assert := assert.New(t)
+
+	eq2 := func(v int, ok bool) func(expectedV int, expectedOk bool) {
+		return func(expectedV int, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedV, v)
+		}
+	}
+
+	{
+		var buf ringbuffer.Pow2[int]
+		assert.Equal(0, buf.Cap())
+		assert.Equal(false, buf.Push(1))
+		eq2(buf.Pop())(0, false)
+		eq2(buf.Peek())(0, false)
+	}
+
+	for capacity, expected := range map[int]int{0: 0, 1: 1, 2: 2, 3: 4, 4: 4, 5: 8, 1000: 1024} {
+		assert.Equal(expected, ringbuffer.NewPow2[int](capacity).Cap())
+	}
+
+	buf := ringbuffer.NewPow2[int](3)
+	for i := 0; i < 5; i++ {
+		for j := 1; j <= 4; j++ {
+			assert.Equal(true, buf.Push(j))
+		}
+		assert.Equal(false, buf.Push(5))
+		assert.Equal(4, buf.Len())
+		eq2(buf.Peek())(1, true)
+		eq2(buf.Pop())(1, true)
+		assert.Equal(true, buf.Push(5))
+		for j := 2; j <= 5; j++ {
+			eq2(buf.Pop())(j, true)
+		}
+		eq2(buf.Pop())(0, false)
+		assert.Equal(0, buf.Len())
+	}
+}
+
+func ExampleNewPow2() {
+	b := ringbuffer.NewPow2[int](100)
+	fmt.Println(b.Cap())
+	// Output: 128
+}
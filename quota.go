@@ -0,0 +1,93 @@
+package ringbuffer
+
+import (
+	"sync"
+)
+
+// Fixed length FIFO queue safe for concurrent use, shared by several producers, each limited to a share of the
+// capacity.
+//
+// Every push names its producer. A producer with as many pending elements as its share can't push more until some
+// of them are popped, while other producers still succeed, so one chatty producer can't monopolize the queue. Every
+// method holds a mutex for its duration. Must not be copied after first use.
+type Quota[K comparable, T any] struct {
+	mu      sync.Mutex
+	buf     Tagged[T, K]
+	share   int
+	shares  map[K]int
+	pending map[K]int
+}
+
+// Create a new queue which can store capacity elements, at most share of them from any single producer.
+func NewQuota[K comparable, T any](capacity, share int) *Quota[K, T] {
+	return &Quota[K, T]{
+		buf:     NewTagged[T, K](capacity),
+		share:   share,
+		pending: make(map[K]int),
+	}
+}
+
+// Override the share of a single producer.
+func (q *Quota[K, T]) SetShare(producer K, share int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.shares == nil {
+		q.shares = make(map[K]int)
+	}
+	q.shares[producer] = share
+}
+
+// How many elements a queue can store?
+func (q *Quota[K, T]) Cap() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.buf.Cap()
+}
+
+// How many elements are currently stored in the queue?
+func (q *Quota[K, T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.buf.Len()
+}
+
+// How many elements of the producer are currently stored in the queue?
+func (q *Quota[K, T]) Pending(producer K) int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pending[producer]
+}
+
+// Push a new element from the producer to the queue.
+//
+// Returns true on success. Returns false if the producer used up its share or there is no free space and push failed.
+func (q *Quota[K, T]) Push(producer K, v T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	share, ok := q.shares[producer]
+	if !ok {
+		share = q.share
+	}
+	if q.pending[producer] >= share || !q.buf.Push(v, producer) {
+		return false
+	}
+	q.pending[producer]++
+	return true
+}
+
+// Try to pop an element from the queue.
+//
+// Returns the producer, the popped element and true on success. Returns default values and false if there were no
+// elements in the queue.
+func (q *Quota[K, T]) Pop() (K, T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	v, producer, ok := q.buf.Pop()
+	if !ok {
+		return producer, v, false
+	}
+	if q.pending[producer]--; q.pending[producer] == 0 {
+		delete(q.pending, producer)
+	}
+	return producer, v, true
+}
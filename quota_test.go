@@ -0,0 +1,96 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestQuota(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var q ringbuffer.Quota[string, int]
+		assert.Equal(false, q.Push("a", 1))
+		assert.Equal(0, q.Cap())
+		_, _, ok := q.Pop()
+		assert.Equal(false, ok)
+	}
+
+	q := ringbuffer.NewQuota[string, int](4, 2)
+	assert.Equal(4, q.Cap())
+	assert.Equal(true, q.Push("chatty", 1))
+	assert.Equal(true, q.Push("chatty", 2))
+	assert.Equal(false, q.Push("chatty", 3))
+	assert.Equal(2, q.Pending("chatty"))
+
+	// others still succeed
+	assert.Equal(true, q.Push("quiet", 4))
+	q.SetShare("vip", 1)
+	assert.Equal(true, q.Push("vip", 5))
+	assert.Equal(false, q.Push("quiet", 6)) // queue is full
+	assert.Equal(4, q.Len())
+
+	// popping returns credit to the producer of the element
+	p, v, ok := q.Pop()
+	assert.Equal(true, ok)
+	assert.Equal("chatty", p)
+	assert.Equal(1, v)
+	assert.Equal(1, q.Pending("chatty"))
+	assert.Equal(false, q.Push("vip", 7))
+	assert.Equal(true, q.Push("chatty", 8))
+	for q.Len() > 0 {
+		q.Pop()
+	}
+	assert.Equal(0, q.Pending("chatty"))
+}
+
+func TestQuotaConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	const share = 100
+	q := ringbuffer.NewQuota[int, int](4*share, share)
+	pushed := make([]int, 4)
+	var wg sync.WaitGroup
+	for p := range pushed {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := share
+			if p == 0 {
+				n = 10 * share // chatty producer
+			}
+			for i := 0; i < n; i++ {
+				if q.Push(p, i) {
+					pushed[p]++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the chatty producer is held at its share, everyone else got all of theirs in
+	assert.Equal([]int{share, share, share, share}, pushed)
+	assert.Equal(4*share, q.Len())
+	p, _, _ := q.Pop()
+	assert.Equal(true, q.Push(p, -1))
+	assert.Equal(false, q.Push(p, -1))
+}
+
+func ExampleQuota() {
+	q := ringbuffer.NewQuota[string, string](8, 2)
+	for _, msg := range []string{"spam", "spam", "spam"} {
+		q.Push("bot", msg)
+	}
+	q.Push("user", "hello")
+	for q.Len() > 0 {
+		p, v, _ := q.Pop()
+		fmt.Println(p, v)
+	}
+	// Output:
+	// bot spam
+	// bot spam
+	// user hello
+}
@@ -0,0 +1,44 @@
+package ringbuffer
+
+// Lightweight read-only position in a RingBuffer, which stays valid across Push, Pop and Resize.
+//
+// Elements are identified by sequence numbers. Popping elements does not affect a read cursor positioned after them.
+// A read cursor becomes invalid when the element it points to is popped, or when elements are renumbered by
+// inserting or deleting through a Cursor. Invalidation is detected, an invalid read cursor never returns elements.
+type ReadCursor[T any] struct {
+	b   *RingBuffer[T]
+	seq uint64
+	gen uint64
+}
+
+// Create a read cursor positioned at the oldest element of the buffer.
+func (b *RingBuffer[T]) ReadCursor() ReadCursor[T] {
+	return ReadCursor[T]{b: b, seq: b.seq, gen: b.gen}
+}
+
+// Does the read cursor still point into the buffer?
+func (r ReadCursor[T]) Valid() bool {
+	return r.b != nil && r.gen == r.b.gen && r.seq >= r.b.seq && r.seq <= r.b.seq+uint64(r.b.Len())
+}
+
+// How many elements are ahead of the read cursor? Returns 0 for invalid read cursors.
+func (r ReadCursor[T]) Len() int {
+	if !r.Valid() {
+		return 0
+	}
+	return int(r.b.seq + uint64(r.b.Len()) - r.seq)
+}
+
+// Read the element under the read cursor and advance it.
+//
+// Returns the element and true on success. Returns default value and false if there are no more elements or if the
+// read cursor was invalidated.
+func (r *ReadCursor[T]) Next() (T, bool) {
+	if r.Len() == 0 {
+		var def T
+		return def, false
+	}
+	v := *r.b.slot(int(r.seq - r.b.seq))
+	r.seq++
+	return v, true
+}
@@ -0,0 +1,83 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReadCursor(t *testing.T) {
+	assert := assert.New(t)
+
+	eq2 := func(v int, ok bool) func(expectedV int, expectedOk bool) {
+		return func(expectedV int, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedV, v)
+		}
+	}
+
+	{
+		var r ringbuffer.ReadCursor[int]
+		assert.Equal(false, r.Valid())
+		eq2(r.Next())(0, false)
+	}
+
+	buf := ringbuffer.New[int](3)
+	r := buf.ReadCursor()
+	assert.Equal(true, r.Valid())
+	eq2(r.Next())(0, false)
+
+	buf.Push(1)
+	buf.Push(2)
+	assert.Equal(2, r.Len())
+	eq2(r.Next())(1, true)
+
+	// popping elements behind the cursor keeps it valid
+	buf.Pop()
+	buf.Push(3)
+	buf.Push(4)
+	assert.Equal(true, r.Valid())
+	assert.Equal(true, buf.Resize(5))
+	eq2(r.Next())(2, true)
+	eq2(r.Next())(3, true)
+
+	// popping the element under the cursor invalidates it
+	lagging := buf.ReadCursor()
+	buf.Pop()
+	assert.Equal(false, lagging.Valid())
+	eq2(lagging.Next())(0, false)
+	eq2(r.Next())(4, true)
+	eq2(r.Next())(0, false)
+
+	// structural changes invalidate all cursors
+	buf.Push(5)
+	r2 := buf.ReadCursor()
+	c := buf.Cursor()
+	c.Next()
+	c.Next()
+	c.Delete()
+	assert.Equal(false, r.Valid())
+	assert.Equal(false, r2.Valid())
+	assert.Equal(0, r2.Len())
+}
+
+func ExampleReadCursor() {
+	b := ringbuffer.New[int](3)
+	b.Push(1)
+	b.Push(2)
+	r := b.ReadCursor()
+	v, _ := r.Next()
+	fmt.Print(v, " ")
+	b.Pop()
+	b.Push(3)
+	for {
+		v, ok := r.Next()
+		if !ok {
+			break
+		}
+		fmt.Print(v, " ")
+	}
+	fmt.Println(r.Valid())
+	// Output: 1 2 3 true
+}
@@ -0,0 +1,119 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Replay produced a different outcome than the one recorded.
+var ErrReplayDiverged = errors.New("ringbuffer: replay diverged from recording")
+
+const (
+	recPush   byte = 0x01
+	recPop    byte = 0x02
+	recFailed byte = 0x80
+)
+
+// Fixed length FIFO ring buffer which records every operation to a log for deterministic replay.
+//
+// Each Push is logged together with its value encoded by the codec, each Pop is logged without value. Outcomes are
+// logged as well, so that Replay can detect divergence. Recording stops at the first write error, see Err.
+type Recorder[T any] struct {
+	buf   RingBuffer[T]
+	w     io.Writer
+	codec Codec[T]
+	err   error
+}
+
+// Create a new recording buffer which can store capacity elements and logs operations to w.
+func NewRecorder[T any](capacity int, w io.Writer, codec Codec[T]) Recorder[T] {
+	return Recorder[T]{
+		buf:   New[T](capacity),
+		w:     w,
+		codec: codec,
+	}
+}
+
+// How many elements a buffer can store?
+func (b Recorder[T]) Cap() int {
+	return b.buf.Cap()
+}
+
+// How many elements are currently stored in the buffer?
+func (b Recorder[T]) Len() int {
+	return b.buf.Len()
+}
+
+// Get the first error which occurred while writing the log, if any.
+func (b Recorder[T]) Err() error {
+	return b.err
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *Recorder[T]) Push(v T) bool {
+	ok := b.buf.Push(v)
+	if b.record(recPush, ok) {
+		if err := b.codec.Encode(b.w, v); err != nil {
+			b.err = err
+		}
+	}
+	return ok
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *Recorder[T]) Pop() (T, bool) {
+	v, ok := b.buf.Pop()
+	b.record(recPop, ok)
+	return v, ok
+}
+
+func (b *Recorder[T]) record(op byte, ok bool) bool {
+	if b.err != nil {
+		return false
+	}
+	if !ok {
+		op |= recFailed
+	}
+	if _, err := b.w.Write([]byte{op}); err != nil {
+		b.err = err
+		return false
+	}
+	return true
+}
+
+// Apply operations recorded by a Recorder to b, which should be a fresh buffer of the same capacity.
+//
+// Returns how many operations were replayed. Returns an error wrapping ErrReplayDiverged if an operation outcome
+// differs from the recorded one.
+func Replay[T any](r io.Reader, codec Codec[T], b *RingBuffer[T]) (int, error) {
+	var op [1]byte
+	for n := 0; ; n++ {
+		if _, err := io.ReadFull(r, op[:]); errors.Is(err, io.EOF) {
+			return n, nil
+		} else if err != nil {
+			return n, err
+		}
+
+		var ok bool
+		switch op[0] &^ recFailed {
+		case recPush:
+			v, err := codec.Decode(r)
+			if err != nil {
+				return n, err
+			}
+			ok = b.Push(v)
+		case recPop:
+			_, ok = b.Pop()
+		default:
+			return n, fmt.Errorf("ringbuffer: unknown operation 0x%02x in recording", op[0])
+		}
+		if ok != (op[0]&recFailed == 0) {
+			return n, fmt.Errorf("%w at operation %d", ErrReplayDiverged, n)
+		}
+	}
+}
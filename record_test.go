@@ -0,0 +1,65 @@
+package ringbuffer_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	assert := assert.New(t)
+	codec := ringbuffer.BinaryCodec[int32]{}
+
+	var log bytes.Buffer
+	rec := ringbuffer.NewRecorder[int32](2, &log, codec)
+	assert.Equal(2, rec.Cap())
+	rec.Push(1)
+	rec.Push(2)
+	rec.Push(3)
+	rec.Pop()
+	rec.Pop()
+	rec.Pop()
+	rec.Push(4)
+	assert.NoError(rec.Err())
+	assert.Equal(1, rec.Len())
+
+	recorded := log.Bytes()
+	fresh := ringbuffer.New[int32](2)
+	n, err := ringbuffer.Replay(bytes.NewReader(recorded), codec, &fresh)
+	assert.NoError(err)
+	assert.Equal(7, n)
+	assert.Equal([]int32{4}, contents(&fresh))
+
+	// a buffer of different capacity behaves differently
+	bigger := ringbuffer.New[int32](3)
+	n, err = ringbuffer.Replay(bytes.NewReader(recorded), codec, &bigger)
+	assert.ErrorIs(err, ringbuffer.ErrReplayDiverged)
+	assert.Equal(2, n)
+
+	// broken log
+	_, err = ringbuffer.Replay(bytes.NewReader([]byte{0x42}), codec, &fresh)
+	assert.Error(err)
+
+	// write errors stop recording
+	failing := ringbuffer.NewRecorder[int32](2, &failingWriter{left: 3}, codec)
+	assert.Equal(true, failing.Push(1))
+	assert.Error(failing.Err())
+	assert.Equal(true, failing.Push(2))
+}
+
+func ExampleReplay() {
+	codec := ringbuffer.BinaryCodec[int32]{}
+	var log bytes.Buffer
+	rec := ringbuffer.NewRecorder[int32](5, &log, codec)
+	rec.Push(1)
+	rec.Push(2)
+	rec.Pop()
+
+	fresh := ringbuffer.New[int32](5)
+	n, err := ringbuffer.Replay(&log, codec, &fresh)
+	v, _ := fresh.Pop()
+	fmt.Println(n, err, v)
+	// Output: 3 <nil> 2
+}
@@ -0,0 +1,67 @@
+package ringbuffer
+
+import (
+	"encoding/binary"
+)
+
+// Size of the length prefix stored in front of every record of a RecordRing.
+const recordHeader = 4
+
+// Fixed size FIFO ring buffer of variable length records, stored with a length prefix in a byte ring.
+//
+// Pushes are all or nothing, a record is either stored whole or not at all, so pops never return partial records
+// even when a record wraps around the end of the storage.
+type RecordRing struct {
+	buf   Bytes
+	count int
+}
+
+// Create a new record ring with capacity bytes of storage, including 4 bytes of overhead per record.
+func NewRecordRing(capacity int) *RecordRing {
+	return &RecordRing{buf: Bytes{buf: New[byte](capacity)}}
+}
+
+// How many bytes of storage the ring has?
+func (r *RecordRing) Cap() int {
+	return r.buf.Cap()
+}
+
+// How many records are currently stored?
+func (r *RecordRing) Len() int {
+	return r.count
+}
+
+// What is the largest record that can be pushed right now?
+func (r *RecordRing) Free() int {
+	return max(r.buf.Free()-recordHeader, 0)
+}
+
+// Push a record to the ring.
+//
+// Returns true on success. Returns false if there is not enough free space for the whole record and push failed.
+func (r *RecordRing) Push(rec []byte) bool {
+	if uint64(len(rec)) > uint64(^uint32(0)) || len(rec) > r.Free() || r.buf.Free() < recordHeader {
+		return false
+	}
+	var hdr [recordHeader]byte
+	binary.LittleEndian.PutUint32(hdr[:], uint32(len(rec)))
+	r.buf.Write(hdr[:])
+	r.buf.Write(rec)
+	r.count++
+	return true
+}
+
+// Try to pop the oldest record from the ring, returning it as a new slice.
+//
+// Returns the record and true on success. Returns nil and false if there were no records in the ring.
+func (r *RecordRing) Pop() ([]byte, bool) {
+	if r.count == 0 {
+		return nil, false
+	}
+	var hdr [recordHeader]byte
+	r.buf.Read(hdr[:])
+	rec := make([]byte, binary.LittleEndian.Uint32(hdr[:]))
+	r.buf.buf.PopMany(rec)
+	r.count--
+	return rec, true
+}
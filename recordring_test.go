@@ -0,0 +1,56 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRecordRing(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		r := ringbuffer.NewRecordRing(0)
+		assert.Equal(false, r.Push(nil))
+		_, ok := r.Pop()
+		assert.Equal(false, ok)
+	}
+
+	r := ringbuffer.NewRecordRing(16)
+	for i := 0; i < 10; i++ {
+		assert.Equal(12, r.Free())
+		assert.Equal(true, r.Push([]byte("hello")))
+		assert.Equal(true, r.Push(nil))
+		assert.Equal(false, r.Push([]byte("world")))
+		assert.Equal(2, r.Len())
+
+		rec, ok := r.Pop()
+		assert.Equal(true, ok)
+		assert.Equal("hello", string(rec))
+		// records wrap around the end of the storage
+		assert.Equal(true, r.Push([]byte("world")))
+		rec, _ = r.Pop()
+		assert.Equal("", string(rec))
+		rec, _ = r.Pop()
+		assert.Equal("world", string(rec))
+		_, ok = r.Pop()
+		assert.Equal(false, ok)
+	}
+	assert.Equal(false, r.Push(make([]byte, 13)))
+	assert.Equal(true, r.Push(make([]byte, 12)))
+	assert.Equal(0, r.Free())
+}
+
+func ExampleRecordRing() {
+	r := ringbuffer.NewRecordRing(64)
+	r.Push([]byte(`{"event":"login"}`))
+	r.Push([]byte(`{"event":"logout"}`))
+	for r.Len() > 0 {
+		rec, _ := r.Pop()
+		fmt.Println(string(rec))
+	}
+	// Output:
+	// {"event":"login"}
+	// {"event":"logout"}
+}
@@ -0,0 +1,142 @@
+package ringbuffer
+
+import (
+	"container/heap"
+	"time"
+)
+
+type retryEntry[T any] struct {
+	ackEntry[T]
+	due time.Time
+}
+
+type retryHeap[T any] []retryEntry[T]
+
+func (h retryHeap[T]) Len() int           { return len(h) }
+func (h retryHeap[T]) Less(i, j int) bool { return h[i].due.Before(h[j].due) }
+func (h retryHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *retryHeap[T]) Push(x any)        { *h = append(*h, x.(retryEntry[T])) }
+func (h *retryHeap[T]) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// Fixed length FIFO queue with acknowledged consumption and delayed redelivery.
+//
+// Works like AckQueue without lease expiry, except that a rejected element becomes visible again only after a
+// backoff delay. The delay starts at base and doubles with every failed attempt, up to maxBackoff. Elements due for
+// redelivery go to the front of the queue, earliest deadline first. Delayed elements count against capacity.
+type RetryQueue[T any] struct {
+	q       AckQueue[T]
+	delayed retryHeap[T]
+	base    time.Duration
+	max     time.Duration
+}
+
+// Create a new retry queue which can store capacity elements, leased and delayed ones included.
+func NewRetryQueue[T any](capacity int, base, maxBackoff time.Duration) RetryQueue[T] {
+	return RetryQueue[T]{
+		q:    NewAckQueue[T](capacity, 0),
+		base: base,
+		max:  maxBackoff,
+	}
+}
+
+// Use the given clock instead of the system clock. Nil restores the system clock.
+func (r *RetryQueue[T]) SetClock(c Clock) {
+	r.q.SetClock(c)
+}
+
+// Move elements to dst once they were rejected after maxAttempts deliveries, see AckQueue.SetDeadLetter.
+func (r *RetryQueue[T]) SetDeadLetter(dst *RingBuffer[T], maxAttempts int) {
+	r.q.SetDeadLetter(dst, maxAttempts)
+}
+
+// Get counters of dead-lettered elements.
+func (r RetryQueue[T]) DeadLetterStats() DeadLetterStats {
+	return r.q.DeadLetterStats()
+}
+
+// How many elements a queue can store?
+func (r RetryQueue[T]) Cap() int {
+	return r.q.Cap()
+}
+
+// How many elements are waiting for delivery, not counting delayed ones?
+func (r RetryQueue[T]) Len() int {
+	return r.q.Len()
+}
+
+// How many elements are leased and not acknowledged yet?
+func (r RetryQueue[T]) InFlight() int {
+	return r.q.InFlight()
+}
+
+// How many rejected elements are waiting for their backoff delay to pass?
+func (r RetryQueue[T]) Delayed() int {
+	return len(r.delayed)
+}
+
+// Get the time when the next delayed element becomes visible. Returns false if there are no delayed elements.
+func (r RetryQueue[T]) NextRetry() (time.Time, bool) {
+	if len(r.delayed) == 0 {
+		return time.Time{}, false
+	}
+	return r.delayed[0].due, true
+}
+
+// Push a new element to the queue.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (r *RetryQueue[T]) Push(v T) bool {
+	if r.q.ready.Len()+len(r.q.leases)+len(r.delayed) >= r.q.Cap() {
+		return false
+	}
+	return r.q.Push(v)
+}
+
+// Lease the oldest element waiting for delivery. Elements whose backoff delay passed are promoted first.
+//
+// Returns the delivery and true on success. Returns default value and false if there were no elements waiting.
+func (r *RetryQueue[T]) Pop() (Delivery[T], bool) {
+	now := clockNow(r.q.clock)
+	var due []ackEntry[T]
+	for len(r.delayed) > 0 && !r.delayed[0].due.After(now) {
+		due = append(due, heap.Pop(&r.delayed).(retryEntry[T]).ackEntry)
+	}
+	for i := len(due) - 1; i >= 0; i-- {
+		r.q.ready.insert(0, due[i])
+	}
+	return r.q.Pop()
+}
+
+// Acknowledge a delivery, removing its element from the queue for good.
+//
+// Returns false if the lease is unknown.
+func (r *RetryQueue[T]) Ack(id uint64) bool {
+	return r.q.Ack(id)
+}
+
+// Reject a delivery, scheduling its element for redelivery after a backoff delay or moving it to the dead-letter
+// buffer.
+//
+// Returns false if the lease is unknown.
+func (r *RetryQueue[T]) Nack(id uint64) bool {
+	l, ok := r.q.leases[id]
+	if !ok {
+		return false
+	}
+	delete(r.q.leases, id)
+	if r.q.bury(l.ackEntry) {
+		return true
+	}
+	delay := r.base
+	for i := 1; i < l.attempts && delay < r.max; i++ {
+		delay *= 2
+	}
+	delay = min(delay, r.max)
+	heap.Push(&r.delayed, retryEntry[T]{ackEntry: l.ackEntry, due: clockNow(r.q.clock).Add(delay)})
+	return true
+}
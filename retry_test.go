@@ -0,0 +1,99 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRetryQueue(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Unix(1000, 0)
+	clock := ringbuffer.NewManualClock(start)
+	q := ringbuffer.NewRetryQueue[string](2, time.Second, 5*time.Second)
+	q.SetClock(clock)
+	assert.Equal(2, q.Cap())
+	assert.Equal(true, q.Push("a"))
+	assert.Equal(true, q.Push("b"))
+
+	// b is held while a keeps failing, backoff: 1s, 2s, 4s, then capped at 5s
+	d, _ := q.Pop()
+	b, _ := q.Pop()
+	assert.Equal("b", b.Value)
+	var delays []time.Duration
+	for i := 0; i < 5; i++ {
+		assert.Equal("a", d.Value)
+		assert.Equal(i+1, d.Attempt)
+		assert.Equal(true, q.Nack(d.ID))
+		assert.Equal(false, q.Nack(d.ID))
+		assert.Equal(1, q.Delayed())
+		assert.Equal(false, q.Push("c"))
+
+		due, ok := q.NextRetry()
+		assert.Equal(true, ok)
+		delays = append(delays, due.Sub(clock.Now()))
+
+		clock.Advance(due.Sub(clock.Now()) - time.Millisecond)
+		_, ok = q.Pop()
+		assert.Equal(false, ok)
+		clock.Advance(time.Millisecond)
+		d, ok = q.Pop()
+		assert.Equal(true, ok)
+	}
+	assert.Equal([]time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}, delays)
+	assert.Equal(true, q.Ack(b.ID))
+	assert.Equal(true, q.Ack(d.ID))
+
+	// drain everything
+	clock.Advance(time.Minute)
+	for {
+		d, ok := q.Pop()
+		if !ok {
+			break
+		}
+		q.Ack(d.ID)
+	}
+	assert.Equal(0, q.Len()+q.Delayed()+q.InFlight())
+	_, ok := q.NextRetry()
+	assert.Equal(false, ok)
+}
+
+func TestRetryQueueDeadLetter(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := ringbuffer.NewManualClock(time.Unix(1000, 0))
+	dead := ringbuffer.New[int](5)
+	q := ringbuffer.NewRetryQueue[int](5, time.Second, time.Minute)
+	q.SetClock(clock)
+	q.SetDeadLetter(&dead, 3)
+	q.Push(42)
+	for i := 0; i < 3; i++ {
+		clock.Advance(time.Hour)
+		d, ok := q.Pop()
+		assert.Equal(true, ok)
+		q.Nack(d.ID)
+	}
+	assert.Equal(0, q.Delayed())
+	assert.Equal([]int{42}, contents(&dead))
+	assert.Equal(ringbuffer.DeadLetterStats{Moved: 1}, q.DeadLetterStats())
+}
+
+func ExampleRetryQueue() {
+	clock := ringbuffer.NewManualClock(time.Unix(1000, 0))
+	q := ringbuffer.NewRetryQueue[string](10, time.Second, time.Minute)
+	q.SetClock(clock)
+	q.Push("flaky")
+	d, _ := q.Pop()
+	q.Nack(d.ID)
+	_, ok := q.Pop()
+	fmt.Println(ok)
+	clock.Advance(time.Second)
+	d, ok = q.Pop()
+	fmt.Println(d.Value, d.Attempt, ok)
+	// Output:
+	// false
+	// flaky 2 true
+}
@@ -31,6 +31,8 @@ package ringbuffer
 
 import (
 	"golang.org/x/exp/constraints"
+	"iter"
+	"slices"
 )
 
 // Fixed length FIFO ring buffer.
@@ -38,6 +40,11 @@ type RingBuffer[T any] struct {
 	read   int
 	write  int
 	buffer []T
+	seq    uint64 // sequence number of the oldest element, counts elements popped so far
+	gen    uint64 // bumped when elements are renumbered, invalidates read cursors
+	policy OverflowPolicy
+	pins   []uint64 // sorted sequence numbers of pinned elements, see Pin
+	pinGen uint64   // gen the pins were added at, pins of older generations are stale
 }
 
 // Create a new buffer which can store capacity elements. The buffer is fixed in length and will not grow.
@@ -58,6 +65,22 @@ func New[T any](capacity int) RingBuffer[T] {
 	}
 }
 
+// Create a new buffer which can store capacity elements, containing elements of initial in FIFO order. Elements
+// past the capacity are ignored, as if pushed one by one. See New.
+func NewFrom[T any](capacity int, initial []T) RingBuffer[T] {
+	b := New[T](capacity)
+	b.PushMany(initial)
+	return b
+}
+
+// Create a new empty buffer using storage as its backing slice instead of allocating one.
+//
+// As with New, one slot is reserved, the buffer can store len(storage)-1 elements. Existing contents of storage are
+// ignored. Resize allocates new storage, the buffer stops using the wrapped slice.
+func Wrap[T any](storage []T) RingBuffer[T] {
+	return RingBuffer[T]{buffer: storage}
+}
+
 // How many elements a buffer can store?
 func (b RingBuffer[T]) Cap() int {
 	return Cap(b.buffer)
@@ -68,18 +91,392 @@ func (b RingBuffer[T]) Len() int {
 	return Len(b.buffer, b.read, b.write)
 }
 
+// How many more elements can be pushed before the buffer is full?
+func (b RingBuffer[T]) Free() int {
+	return Free(b.buffer, b.read, b.write)
+}
+
+// Are there no elements in the buffer?
+func (b RingBuffer[T]) IsEmpty() bool {
+	return IsEmpty(b.buffer, b.read, b.write)
+}
+
+// Is there no free space left in the buffer? A zero capacity buffer is always full, as well as empty.
+func (b RingBuffer[T]) IsFull() bool {
+	return IsFull(b.buffer, b.read, b.write)
+}
+
 // Push a new element to the buffer.
 //
-// Returns true on success. Returns false if there is no free space and push failed.
+// Returns true on success. When there is no free space, the outcome depends on the overflow policy, see
+// NewWithOptions. With the default Reject policy, returns false and push fails. So does DropOldest when all stored
+// elements are pinned, see Pin.
 func (b *RingBuffer[T]) Push(v T) bool {
-	return Push(b.buffer, b.read, &b.write, v)
+	if b.Cap() == 0 {
+		return false
+	}
+	switch b.policy {
+	case DropOldest:
+		if len(b.pins) > 0 && b.IsFull() {
+			_, ok := b.overwritePinned(v)
+			return ok
+		}
+		b.PushOverwrite(v)
+		return true
+	case DropNewest:
+		b.PushMerge(v, func(_, v T) T { return v })
+		return true
+	default:
+		return Push(b.buffer, b.read, &b.write, v)
+	}
+}
+
+// Push as many elements from vs as possible to the buffer, in order.
+//
+// Returns the number of elements accepted. With the default Reject policy, elements are accepted while there is free
+// space. Other overflow policies accept all of vs, handling the overflow as Push would one element at a time, except
+// that DropOldest stops at the first element not accepted because all stored elements are pinned.
+func (b *RingBuffer[T]) PushMany(vs []T) int {
+	if b.Cap() == 0 {
+		return 0
+	}
+	n := len(vs)
+	switch b.policy {
+	case DropOldest:
+		if b.prunePins(); len(b.pins) > 0 {
+			for i, v := range vs {
+				if !b.Push(v) {
+					return i
+				}
+			}
+			return n
+		}
+		// elements not fitting at all would be evicted by the ones after them, they still count as popped
+		skip := max(0, len(vs)-b.Cap())
+		vs = vs[skip:]
+		b.seq += uint64(skip)
+		if drop := len(vs) - b.Free(); drop > 0 {
+			b.read = (b.read + drop) % len(b.buffer)
+			b.seq += uint64(drop)
+		}
+		b.pushFree(vs)
+	case DropNewest:
+		if c := b.pushFree(vs); c < len(vs) {
+			*b.slot(b.Len() - 1) = vs[len(vs)-1]
+		}
+	default:
+		n = b.pushFree(vs)
+	}
+	return n
+}
+
+// Copy elements from vs to the free space of the buffer, returns how many were copied.
+func (b *RingBuffer[T]) pushFree(vs []T) int {
+	x, y := b.freeRegions()
+	n := copy(x, vs)
+	n += copy(y, vs[n:])
+	b.write = (b.write + n) % len(b.buffer)
+	return n
+}
+
+// Push a new element to the buffer. If there is no free space, evict the oldest element to make room.
+//
+// Returns the evicted element and true if eviction happened. Returns default value and false otherwise. A buffer with
+// zero capacity can't store anything, the pushed element itself is returned as evicted, and so it is when all stored
+// elements are pinned. Pinned elements are skipped by eviction, see Pin.
+func (b *RingBuffer[T]) PushOverwrite(v T) (T, bool) {
+	if len(b.pins) > 0 && b.IsFull() && b.Cap() > 0 {
+		ev, _ := b.overwritePinned(v)
+		return ev, true
+	}
+	ev, ok := PushOverwrite(b.buffer, &b.read, &b.write, v)
+	if ok && b.Cap() > 0 {
+		b.seq++
+	}
+	return ev, ok
+}
+
+// Push a new element to the buffer. If there is no free space, combine it with the newest stored element instead.
+//
+// The newest element is replaced with merge(newest, v), e.g. to sum counters or keep the latest state under pressure.
+// Returns true if the element was pushed. Returns false if it was merged, or if the buffer has zero capacity.
+func (b *RingBuffer[T]) PushMerge(v T, merge func(old, new T) T) bool {
+	return PushMerge(b.buffer, b.read, &b.write, v, merge)
+}
+
+// Push a new element in front of the oldest one, so that it is popped next.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *RingBuffer[T]) PushFront(v T) bool {
+	nonEmpty := b.read != b.write
+	if !PushFront(b.buffer, &b.read, b.write, v) {
+		return false
+	}
+	if nonEmpty {
+		b.gen++ // existing elements are renumbered
+	}
+	return true
 }
 
 // Try to pop an element from the buffer.
 //
 // Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
 func (b *RingBuffer[T]) Pop() (T, bool) {
-	return Pop(b.buffer, &b.read, b.write)
+	v, ok := Pop(b.buffer, &b.read, b.write)
+	if ok {
+		b.seq++
+	}
+	return v, ok
+}
+
+// Pop up to len(dst) elements from the buffer into dst, in FIFO order.
+//
+// Returns the number of elements popped.
+func (b *RingBuffer[T]) PopMany(dst []T) int {
+	x, y := b.Regions()
+	n := copy(dst, x)
+	n += copy(dst[n:], y)
+	if n > 0 {
+		b.read = (b.read + n) % len(b.buffer)
+		b.seq += uint64(n)
+	}
+	return n
+}
+
+// Pop all elements from the buffer, returning them in FIFO order as a new slice.
+func (b *RingBuffer[T]) Drain() []T {
+	out := make([]T, b.Len())
+	b.PopMany(out)
+	return out
+}
+
+// Try to pop the most recently pushed element from the buffer, using it as a stack.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *RingBuffer[T]) PopBack() (T, bool) {
+	v, ok := PopBack(b.buffer, b.read, &b.write)
+	if ok {
+		b.gen++ // the next push reuses the sequence number
+	}
+	return v, ok
+}
+
+// Look at the oldest element without removing it from the buffer.
+//
+// Returns the oldest element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b RingBuffer[T]) Peek() (T, bool) {
+	return Peek(b.buffer, b.read, b.write)
+}
+
+// Look at the most recently pushed element without removing it from the buffer.
+//
+// Returns the newest element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b RingBuffer[T]) Back() (T, bool) {
+	return Back(b.buffer, b.read, b.write)
+}
+
+// Get the element at logical index i, 0 being the oldest element.
+//
+// Returns the element and true on success. Returns default value and false if i is out of range.
+func (b RingBuffer[T]) At(i int) (T, bool) {
+	return At(b.buffer, b.read, b.write, i)
+}
+
+// Replace the element at logical index i, 0 being the oldest element.
+//
+// Returns true on success. Returns false if i is out of range.
+func (b *RingBuffer[T]) Set(i int, v T) bool {
+	return Set(b.buffer, b.read, b.write, i, v)
+}
+
+// Get stored elements in FIFO order as (up to) two contiguous regions of the underlying slice.
+//
+// The second region is empty unless stored elements wrap around the end of the slice. No copying is done, regions
+// alias buffer storage and are only valid until the buffer is modified.
+func (b RingBuffer[T]) Regions() ([]T, []T) {
+	return Regions(b.buffer, b.read, b.write)
+}
+
+// Append stored elements in FIFO order to dst and return the extended slice. The buffer is not modified.
+func (b RingBuffer[T]) AppendTo(dst []T) []T {
+	x, y := b.Regions()
+	return append(append(dst, x...), y...)
+}
+
+// Call f for each stored element in FIFO order. The buffer must not be modified by f.
+func (b RingBuffer[T]) Do(f func(v T)) {
+	x, y := b.Regions()
+	for _, v := range x {
+		f(v)
+	}
+	for _, v := range y {
+		f(v)
+	}
+}
+
+// Iterate over stored elements in FIFO order. The buffer must not be modified during iteration.
+func (b RingBuffer[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range b.Len() {
+			if !yield(*b.slot(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over logical indices and stored elements in FIFO order, 0 being the oldest element. The buffer must not be
+// modified during iteration.
+func (b RingBuffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := range b.Len() {
+			if !yield(i, *b.slot(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over logical indices and stored elements from the newest to the oldest. The buffer must not be modified
+// during iteration.
+func (b RingBuffer[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := b.Len() - 1; i >= 0; i-- {
+			if !yield(i, *b.slot(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Get free space following the write position as (up to) two contiguous regions of the underlying slice.
+func (b *RingBuffer[T]) freeRegions() ([]T, []T) {
+	if len(b.buffer) == 0 {
+		return nil, nil
+	}
+	if b.write >= b.read {
+		if b.read == 0 {
+			return b.buffer[b.write : len(b.buffer)-1], nil
+		}
+		return b.buffer[b.write:], b.buffer[:b.read-1]
+	} else {
+		return b.buffer[b.write : b.read-1], nil
+	}
+}
+
+// Mark n elements at the start of the free regions as stored, after they were filled in place.
+func (b *RingBuffer[T]) commit(n int) {
+	if n > 0 {
+		b.write = (b.write + n) % len(b.buffer)
+	}
+}
+
+// Drop n oldest elements, as if popped.
+func (b *RingBuffer[T]) consume(n int) {
+	if n > 0 {
+		b.read = (b.read + n) % len(b.buffer)
+		b.seq += uint64(n)
+	}
+}
+
+// Transfer up to n elements from the front of the buffer to the back of dst, preserving FIFO order.
+//
+// Elements are moved with bulk copies, no intermediate storage is allocated. Returns how many elements were moved,
+// which is limited by the number of stored elements and by free space in dst.
+func (b *RingBuffer[T]) MoveN(dst *RingBuffer[T], n int) int {
+	n = min(n, b.Len(), dst.Cap()-dst.Len())
+	moved := 0
+	for moved < n {
+		s, _ := b.Regions()
+		d, _ := dst.freeRegions()
+		c := copy(d[:min(len(d), n-moved)], s)
+		b.read = (b.read + c) % len(b.buffer)
+		b.seq += uint64(c)
+		dst.write = (dst.write + c) % len(dst.buffer)
+		moved += c
+	}
+	return moved
+}
+
+// Split stored elements into two new buffers in one pass, depending on whether they satisfy pred.
+//
+// Both new buffers have the same capacity as the original one, FIFO order is preserved. The original buffer is not
+// modified.
+func (b RingBuffer[T]) Partition(pred func(T) bool) (matched, rest RingBuffer[T]) {
+	matched = New[T](b.Cap())
+	rest = New[T](b.Cap())
+	x, y := b.Regions()
+	for _, r := range [2][]T{x, y} {
+		for _, v := range r {
+			if pred(v) {
+				matched.Push(v)
+			} else {
+				rest.Push(v)
+			}
+		}
+	}
+	return matched, rest
+}
+
+// Drop elements superseded by a newer element with the same key, keeping the newest one per key.
+//
+// Surviving elements keep their relative order. Returns how many elements were dropped.
+func Compact[T any, K comparable](b *RingBuffer[T], key func(T) K) int {
+	n := b.Len()
+	seen := make(map[K]struct{}, n)
+	keep := make([]bool, n)
+	for i := n - 1; i >= 0; i-- {
+		k := key(*b.slot(i))
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			keep[i] = true
+		}
+	}
+	kept := 0
+	for i := 0; i < n; i++ {
+		if keep[i] {
+			*b.slot(kept) = *b.slot(i)
+			kept++
+		}
+	}
+	var def T
+	for i := kept; i < n; i++ {
+		*b.slot(i) = def
+	}
+	if kept < n {
+		b.write = (b.read + kept) % len(b.buffer)
+		b.gen++
+	}
+	return n - kept
+}
+
+// Change the capacity of the buffer, preserving stored elements in FIFO order.
+//
+// Elements are migrated to a newly allocated slice. Returns true on success. Returns false and leaves the buffer
+// unchanged if it currently stores more elements than the new capacity allows.
+func (b *RingBuffer[T]) Resize(capacity int) bool {
+	n := b.Len()
+	if capacity < n {
+		return false
+	}
+	nb := New[T](capacity)
+	x, y := b.Regions()
+	copy(nb.buffer[copy(nb.buffer, x):], y)
+	nb.write = n
+	nb.seq = b.seq
+	nb.gen = b.gen
+	nb.policy = b.policy
+	nb.pins = b.pins
+	nb.pinGen = b.pinGen
+	*b = nb
+	return true
+}
+
+// Make an independent copy of the buffer, with its own backing storage.
+func (b RingBuffer[T]) Clone() RingBuffer[T] {
+	b.buffer = slices.Clone(b.buffer)
+	b.pins = slices.Clone(b.pins)
+	return b
 }
 
 // How many elements a buffer can store?
@@ -100,6 +497,35 @@ func Len[T any, U constraints.Integer](slice []T, read, write U) int {
 	}
 }
 
+// How many more elements can be pushed before the buffer is full?
+func Free[T any, U constraints.Integer](slice []T, read, write U) int {
+	return Cap(slice) - Len(slice, read, write)
+}
+
+// Are there no elements in the buffer?
+func IsEmpty[T any, U constraints.Integer](slice []T, read, write U) bool {
+	return read == write
+}
+
+// Is there no free space left in the buffer? A zero capacity buffer is always full, as well as empty.
+func IsFull[T any, U constraints.Integer](slice []T, read, write U) bool {
+	if len(slice) == 0 {
+		return true
+	}
+	return (int(write)+1)%len(slice) == int(read)
+}
+
+// Get stored elements in FIFO order as (up to) two contiguous regions of the slice.
+//
+// The second region is empty unless stored elements wrap around the end of the slice.
+func Regions[T any, U constraints.Integer](slice []T, read, write U) ([]T, []T) {
+	if write >= read {
+		return slice[read:write], nil
+	} else {
+		return slice[read:], slice[:write]
+	}
+}
+
 // Push a new element to the buffer.
 //
 // Returns true on success. Returns false if there is no free space and push failed.
@@ -116,6 +542,54 @@ func Push[T any, U constraints.Integer](slice []T, read U, write *U, v T) bool {
 	return true
 }
 
+// Push a new element to the buffer. If there is no free space, evict the oldest element to make room.
+//
+// Returns the evicted element and true if eviction happened. Returns default value and false otherwise. A buffer with
+// zero capacity can't store anything, the pushed element itself is returned as evicted.
+func PushOverwrite[T any, U constraints.Integer](slice []T, read, write *U, v T) (T, bool) {
+	if Cap(slice) == 0 {
+		return v, true
+	}
+	if Push(slice, *read, write, v) {
+		var def T
+		return def, false
+	}
+	ev, _ := Pop(slice, read, *write)
+	Push(slice, *read, write, v)
+	return ev, true
+}
+
+// Push a new element to the buffer. If there is no free space, combine it with the newest stored element instead.
+//
+// Returns true if the element was pushed. Returns false if it was merged, or if the buffer has zero capacity.
+func PushMerge[T any, U constraints.Integer](slice []T, read U, write *U, v T, merge func(old, new T) T) bool {
+	if Cap(slice) == 0 {
+		return false
+	}
+	if Push(slice, read, write, v) {
+		return true
+	}
+	newest := (int(*write) - 1 + len(slice)) % len(slice)
+	slice[newest] = merge(slice[newest], v)
+	return false
+}
+
+// Push a new element in front of the oldest one, so that it is popped next.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func PushFront[T any, U constraints.Integer](slice []T, read *U, write U, v T) bool {
+	if len(slice) == 0 {
+		return false
+	}
+	prev := (int(*read) - 1 + len(slice)) % len(slice)
+	if prev == int(write) {
+		return false // no more space
+	}
+	slice[prev] = v
+	*read = U(prev)
+	return true
+}
+
 // Try to pop an element from the buffer.
 //
 // Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
@@ -128,3 +602,59 @@ func Pop[T any, U constraints.Integer](slice []T, read *U, write U) (T, bool) {
 	*read = U(int(*read+1) % len(slice))
 	return val, true
 }
+
+// Try to pop the most recently pushed element from the buffer, using it as a stack.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func PopBack[T any, U constraints.Integer](slice []T, read U, write *U) (T, bool) {
+	if read == *write {
+		var def T
+		return def, false
+	}
+	*write = U((int(*write) - 1 + len(slice)) % len(slice))
+	return slice[*write], true
+}
+
+// Look at the oldest element without removing it from the buffer.
+//
+// Returns the oldest element and true on success. Returns default value and false if there were no elements in the buffer.
+func Peek[T any, U constraints.Integer](slice []T, read, write U) (T, bool) {
+	if read == write {
+		var def T
+		return def, false
+	}
+	return slice[read], true
+}
+
+// Look at the most recently pushed element without removing it from the buffer.
+//
+// Returns the newest element and true on success. Returns default value and false if there were no elements in the buffer.
+func Back[T any, U constraints.Integer](slice []T, read, write U) (T, bool) {
+	if read == write {
+		var def T
+		return def, false
+	}
+	return slice[(int(write)-1+len(slice))%len(slice)], true
+}
+
+// Get the element at logical index i, 0 being the oldest element.
+//
+// Returns the element and true on success. Returns default value and false if i is out of range.
+func At[T any, U constraints.Integer](slice []T, read, write U, i int) (T, bool) {
+	if i < 0 || i >= Len(slice, read, write) {
+		var def T
+		return def, false
+	}
+	return slice[(int(read)+i)%len(slice)], true
+}
+
+// Replace the element at logical index i, 0 being the oldest element.
+//
+// Returns true on success. Returns false if i is out of range.
+func Set[T any, U constraints.Integer](slice []T, read, write U, i int, v T) bool {
+	if i < 0 || i >= Len(slice, read, write) {
+		return false
+	}
+	slice[(int(read)+i)%len(slice)] = v
+	return true
+}
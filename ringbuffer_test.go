@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/nsf/ringbuffer"
 	"github.com/stretchr/testify/assert"
+	"slices"
 	"testing"
 )
 
@@ -115,6 +116,752 @@ func TestRingBuffer(t *testing.T) {
 	}
 }
 
+func TestNewFrom(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := ringbuffer.NewFrom(0, []int{1, 2})
+	assert.Equal(0, buf.Cap())
+	assert.Equal(0, buf.Len())
+
+	buf = ringbuffer.NewFrom[int](3, nil)
+	assert.Equal(3, buf.Cap())
+	assert.Equal(0, buf.Len())
+
+	buf = ringbuffer.NewFrom(3, []int{1, 2})
+	assert.Equal(3, buf.Cap())
+	assert.Equal([]int{1, 2}, contents(&buf))
+
+	buf = ringbuffer.NewFrom(3, []int{1, 2, 3, 4, 5})
+	assert.Equal([]int{1, 2, 3}, contents(&buf))
+}
+
+func TestWrap(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		buf := ringbuffer.Wrap[int](nil)
+		assert.Equal(0, buf.Cap())
+		assert.Equal(false, buf.Push(1))
+		storage := make([]int, 1)
+		buf = ringbuffer.Wrap(storage)
+		assert.Equal(0, buf.Cap())
+		assert.Equal(false, buf.Push(1))
+
+		// the only slot is the reserved one, nothing is written into it
+		ev, ok := buf.PushOverwrite(2)
+		assert.Equal(2, ev)
+		assert.Equal(true, ok)
+		assert.Equal(false, buf.PushMerge(3, func(a, b int) int { return a + b }))
+		assert.Equal([]int{0}, storage)
+		assert.Equal(uint64(0), buf.Seq())
+		assert.Equal(0, buf.Len())
+
+		ev, ok = ringbuffer.PushOverwrite(storage, new(int), new(int), 4)
+		assert.Equal(4, ev)
+		assert.Equal(true, ok)
+		assert.Equal(false, ringbuffer.PushMerge(storage, 0, new(int), 5, func(a, b int) int { return b }))
+		assert.Equal([]int{0}, storage)
+	}
+
+	storage := make([]int, 4)
+	buf := ringbuffer.Wrap(storage)
+	assert.Equal(3, buf.Cap())
+	assert.Equal(0, buf.Len())
+	for i := 1; i <= 3; i++ {
+		assert.Equal(true, buf.Push(i))
+	}
+	assert.Equal(false, buf.Push(4))
+	assert.Equal([]int{1, 2, 3, 0}, storage)
+	buf.Pop()
+	buf.Push(4)
+	assert.Equal([]int{1, 2, 3, 4}, storage)
+	assert.Equal([]int{2, 3, 4}, contents(&buf))
+}
+
+func TestRingBufferClone(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		c := buf.Clone()
+		assert.Equal(0, c.Cap())
+	}
+
+	buf := ringbuffer.NewWithOptions[int](3, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest))
+	for i := 1; i <= 5; i++ {
+		buf.Push(i)
+	}
+	c := buf.Clone()
+	assert.Equal([]int{3, 4, 5}, contents(&c))
+
+	buf.Pop()
+	buf.Push(6)
+	assert.Equal([]int{4, 5, 6}, contents(&buf))
+	assert.Equal([]int{3, 4, 5}, contents(&c))
+
+	// policy is kept
+	c.Push(7)
+	assert.Equal([]int{4, 5, 7}, contents(&c))
+}
+
+func TestRingBufferResize(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := ringbuffer.New[int](3)
+	buf.Push(1)
+	buf.Pop()
+	buf.Push(2)
+	buf.Push(3)
+	buf.Push(4)
+	assert.Equal(false, buf.Resize(2))
+	assert.Equal(3, buf.Cap())
+	assert.Equal(true, buf.Resize(5))
+	assert.Equal(5, buf.Cap())
+	assert.Equal(3, buf.Len())
+	assert.Equal(true, buf.Push(5))
+	for i := 2; i <= 5; i++ {
+		v, ok := buf.Pop()
+		assert.Equal(true, ok)
+		assert.Equal(i, v)
+	}
+	assert.Equal(true, buf.Resize(0))
+	assert.Equal(0, buf.Cap())
+	assert.Equal(false, buf.Push(1))
+}
+
+func TestRingBufferRegions(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		a, b := buf.Regions()
+		assert.Empty(a)
+		assert.Empty(b)
+	}
+
+	buf := ringbuffer.New[int](3)
+	buf.Push(1)
+	buf.Push(2)
+	a, b := buf.Regions()
+	assert.Equal([]int{1, 2}, a)
+	assert.Empty(b)
+
+	buf.Pop()
+	buf.Push(3)
+	buf.Push(4)
+	a, b = buf.Regions()
+	assert.Equal([]int{2, 3, 4}, a)
+	assert.Empty(b)
+
+	buf.Pop()
+	buf.Push(5)
+	a, b = buf.Regions()
+	assert.Equal([]int{3, 4}, a)
+	assert.Equal([]int{5}, b)
+
+	buf.Pop()
+	buf.Pop()
+	a, b = buf.Regions()
+	assert.Equal([]int{5}, a)
+	assert.Empty(b)
+}
+
+func TestRingBufferMoveN(t *testing.T) {
+	assert := assert.New(t)
+
+	drain := func(b *ringbuffer.RingBuffer[int]) []int {
+		var out []int
+		for {
+			v, ok := b.Pop()
+			if !ok {
+				return out
+			}
+			out = append(out, v)
+		}
+	}
+
+	{
+		var src, dst ringbuffer.RingBuffer[int]
+		assert.Equal(0, src.MoveN(&dst, 5))
+	}
+
+	for srcOff := 0; srcOff < 6; srcOff++ {
+		for dstOff := 0; dstOff < 5; dstOff++ {
+			src := ringbuffer.New[int](5)
+			dst := ringbuffer.New[int](4)
+			for i := 0; i < srcOff; i++ {
+				src.Push(0)
+				src.Pop()
+			}
+			for i := 0; i < dstOff; i++ {
+				dst.Push(0)
+				dst.Pop()
+			}
+			for i := 1; i <= 5; i++ {
+				src.Push(i)
+			}
+			dst.Push(0)
+			assert.Equal(0, src.MoveN(&dst, 0))
+			assert.Equal(2, src.MoveN(&dst, 2))
+			assert.Equal(1, src.MoveN(&dst, 10))
+			assert.Equal(0, src.MoveN(&dst, 10))
+			assert.Equal([]int{0, 1, 2, 3}, drain(&dst))
+			assert.Equal([]int{4, 5}, drain(&src))
+		}
+	}
+}
+
+func TestRingBufferPartition(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		m, r := buf.Partition(func(int) bool { return true })
+		assert.Equal(0, m.Cap())
+		assert.Equal(0, r.Cap())
+	}
+
+	buf := ringbuffer.New[int](4)
+	buf.Push(0)
+	buf.Push(0)
+	buf.Pop()
+	buf.Pop()
+	for i := 1; i <= 4; i++ {
+		buf.Push(i)
+	}
+	odd, even := buf.Partition(func(v int) bool { return v%2 == 1 })
+	assert.Equal(4, buf.Len())
+	assert.Equal(4, odd.Cap())
+	assert.Equal(4, even.Cap())
+	assert.Equal([]int{1, 3}, contents(&odd))
+	assert.Equal([]int{2, 4}, contents(&even))
+}
+
+func TestCompact(t *testing.T) {
+	assert := assert.New(t)
+
+	type kv struct {
+		k string
+		v int
+	}
+	key := func(e kv) string { return e.k }
+
+	{
+		var buf ringbuffer.RingBuffer[kv]
+		assert.Equal(0, ringbuffer.Compact(&buf, key))
+	}
+
+	buf := ringbuffer.New[kv](6)
+	for i := 0; i < 5; i++ {
+		buf.Push(kv{})
+		buf.Pop()
+	}
+	for i, k := range []string{"a", "b", "a", "c", "b", "a"} {
+		buf.Push(kv{k, i})
+	}
+	r := buf.ReadCursor()
+	assert.Equal(3, ringbuffer.Compact(&buf, key))
+	assert.Equal([]kv{{"c", 3}, {"b", 4}, {"a", 5}}, contents(&buf))
+	assert.Equal(false, r.Valid())
+
+	r = buf.ReadCursor()
+	assert.Equal(0, ringbuffer.Compact(&buf, key))
+	assert.Equal(true, r.Valid())
+	assert.Equal(true, buf.Push(kv{"d", 6}))
+	assert.Equal(4, buf.Len())
+}
+
+func TestRingBufferPopBack(t *testing.T) {
+	assert := assert.New(t)
+
+	eq2 := func(v int, ok bool) func(expectedV int, expectedOk bool) {
+		return func(expectedV int, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedV, v)
+		}
+	}
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		eq2(buf.PopBack())(0, false)
+	}
+
+	buf := ringbuffer.New[int](3)
+	for i := 0; i < 5; i++ {
+		buf.Push(1)
+		buf.Push(2)
+		buf.Push(3)
+		eq2(buf.PopBack())(3, true)
+		assert.Equal(true, buf.Push(4))
+		eq2(buf.PopBack())(4, true)
+		eq2(buf.Pop())(1, true)
+		eq2(buf.PopBack())(2, true)
+		eq2(buf.PopBack())(0, false)
+		eq2(buf.Pop())(0, false)
+		assert.Equal(0, buf.Len())
+	}
+
+	// read cursors can't observe the reused slot
+	buf.Push(1)
+	buf.Push(2)
+	r := buf.ReadCursor()
+	buf.PopBack()
+	buf.Push(3)
+	assert.Equal(false, r.Valid())
+}
+
+func TestRingBufferIsFullIsEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		assert.Equal(true, buf.IsEmpty())
+		assert.Equal(true, buf.IsFull())
+		assert.Equal(true, ringbuffer.IsFull[int](nil, 0, 0))
+		assert.Equal(0, buf.Free())
+	}
+
+	buf := ringbuffer.New[int](2)
+	for i := 0; i < 5; i++ {
+		assert.Equal(true, buf.IsEmpty())
+		assert.Equal(false, buf.IsFull())
+		assert.Equal(2, buf.Free())
+		buf.Push(1)
+		assert.Equal(false, buf.IsEmpty())
+		assert.Equal(false, buf.IsFull())
+		assert.Equal(1, buf.Free())
+		buf.Push(2)
+		assert.Equal(false, buf.IsEmpty())
+		assert.Equal(true, buf.IsFull())
+		assert.Equal(0, buf.Free())
+		buf.Pop()
+		buf.Pop()
+	}
+}
+
+func TestRingBufferPushMany(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		assert.Equal(0, buf.PushMany([]int{1, 2}))
+	}
+
+	buf := ringbuffer.New[int](5)
+	// move cursors so that free space wraps around
+	for i := 0; i < 3; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	assert.Equal(0, buf.PushMany(nil))
+	assert.Equal(2, buf.PushMany([]int{1, 2}))
+	assert.Equal(3, buf.PushMany([]int{3, 4, 5, 6, 7}))
+	assert.Equal(0, buf.PushMany([]int{8}))
+	assert.Equal([]int{1, 2, 3, 4, 5}, contents(&buf))
+
+	oldest := ringbuffer.NewWithOptions[int](3, ringbuffer.WithOverflowPolicy(ringbuffer.DropOldest))
+	oldest.Push(1)
+	assert.Equal(3, oldest.PushMany([]int{2, 3, 4}))
+	assert.Equal([]int{2, 3, 4}, contents(&oldest))
+	r := oldest.ReadCursor()
+	r.Next()
+	assert.Equal(1, oldest.PushMany([]int{5}))
+	v, ok := r.Next()
+	assert.Equal(true, ok)
+	assert.Equal(3, v)
+	// the read cursor would miss evicted elements
+	assert.Equal(5, oldest.PushMany([]int{6, 7, 8, 9, 10}))
+	assert.Equal([]int{8, 9, 10}, contents(&oldest))
+	assert.Equal(false, r.Valid())
+
+	newest := ringbuffer.NewWithOptions[int](3, ringbuffer.WithOverflowPolicy(ringbuffer.DropNewest))
+	newest.Push(1)
+	assert.Equal(4, newest.PushMany([]int{2, 3, 4, 5}))
+	assert.Equal([]int{1, 2, 5}, contents(&newest))
+}
+
+func TestRingBufferPopMany(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		assert.Equal(0, buf.PopMany(make([]int, 2)))
+	}
+
+	buf := ringbuffer.New[int](5)
+	// move cursors so that contents wrap around
+	for i := 0; i < 3; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	buf.PushMany([]int{1, 2, 3, 4, 5})
+	r := buf.ReadCursor()
+	r.Next()
+	r.Next()
+
+	dst := make([]int, 4)
+	assert.Equal(0, buf.PopMany(nil))
+	assert.Equal(2, buf.PopMany(dst[:2]))
+	assert.Equal([]int{1, 2}, dst[:2])
+	assert.Equal(true, r.Valid())
+	assert.Equal(3, buf.PopMany(dst))
+	assert.Equal([]int{3, 4, 5}, dst[:3])
+	assert.Equal(0, buf.PopMany(dst))
+	assert.Equal(true, buf.IsEmpty())
+	assert.Equal(false, r.Valid())
+}
+
+func TestRingBufferDrain(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		assert.Equal([]int{}, buf.Drain())
+	}
+
+	buf := ringbuffer.New[int](3)
+	for i := 1; i <= 5; i++ {
+		buf.Push(i)
+		buf.Push(i * 10)
+		assert.Equal([]int{i, i * 10}, buf.Drain())
+		assert.Equal(true, buf.IsEmpty())
+	}
+}
+
+func TestRingBufferAppendTo(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		assert.Equal([]int(nil), buf.AppendTo(nil))
+	}
+
+	buf := ringbuffer.New[int](4)
+	// move cursors so that contents wrap around
+	for i := 0; i < 3; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	buf.PushMany([]int{1, 2, 3})
+	snap := make([]int, 0, 8)
+	for i := 0; i < 3; i++ {
+		snap = buf.AppendTo(snap[:0])
+		assert.Equal([]int{1, 2, 3}, snap)
+	}
+	assert.Equal([]int{0, 1, 2, 3}, buf.AppendTo([]int{0}))
+	assert.Equal(3, buf.Len())
+}
+
+func TestRingBufferPushFront(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		assert.Equal(false, buf.PushFront(1))
+	}
+
+	buf := ringbuffer.New[int](3)
+	for i := 0; i < 5; i++ {
+		assert.Equal(true, buf.PushFront(2))
+		assert.Equal(true, buf.PushFront(1))
+		assert.Equal(true, buf.Push(3))
+		assert.Equal(false, buf.PushFront(0))
+		assert.Equal([]int{1, 2, 3}, contents(&buf))
+		v, _ := buf.PopBack()
+		assert.Equal(3, v)
+		v, _ = buf.Pop()
+		assert.Equal(1, v)
+		v, _ = buf.Pop()
+		assert.Equal(2, v)
+		assert.Equal(0, buf.Len())
+	}
+
+	// read cursors get invalidated by renumbering
+	buf.Push(1)
+	r := buf.ReadCursor()
+	buf.PushFront(0)
+	assert.Equal(false, r.Valid())
+}
+
+func TestRingBufferPushOverwrite(t *testing.T) {
+	assert := assert.New(t)
+
+	eq2 := func(v int, ok bool) func(expectedV int, expectedOk bool) {
+		return func(expectedV int, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedV, v)
+		}
+	}
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		eq2(buf.PushOverwrite(5))(5, true)
+		assert.Equal(0, buf.Len())
+	}
+
+	buf := ringbuffer.New[int](3)
+	eq2(buf.PushOverwrite(1))(0, false)
+	eq2(buf.PushOverwrite(2))(0, false)
+	eq2(buf.PushOverwrite(3))(0, false)
+	r := buf.ReadCursor()
+	r.Next()
+	for i := 4; i < 10; i++ {
+		eq2(buf.PushOverwrite(i))(i-3, true)
+		assert.Equal(3, buf.Len())
+	}
+	assert.Equal([]int{7, 8, 9}, contents(&buf))
+	assert.Equal(false, r.Valid())
+
+	// cursors ahead of evictions stay valid
+	r = buf.ReadCursor()
+	r.Next()
+	r.Next()
+	buf.Pop()
+	eq2(buf.PushOverwrite(10))(0, false)
+	eq2(buf.PushOverwrite(11))(8, true)
+	assert.Equal(true, r.Valid())
+	eq2(r.Next())(9, true)
+}
+
+func TestRingBufferPushMerge(t *testing.T) {
+	assert := assert.New(t)
+	sum := func(a, b int) int { return a + b }
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		assert.Equal(false, buf.PushMerge(1, sum))
+		assert.Equal(0, buf.Len())
+	}
+
+	buf := ringbuffer.New[int](2)
+	buf.Push(0)
+	buf.Pop()
+	assert.Equal(true, buf.PushMerge(1, sum))
+	assert.Equal(true, buf.PushMerge(2, sum))
+	assert.Equal(false, buf.PushMerge(3, sum))
+	assert.Equal(false, buf.PushMerge(4, sum))
+	assert.Equal([]int{1, 9}, contents(&buf))
+	buf.Pop()
+	assert.Equal(true, buf.PushMerge(5, sum))
+	assert.Equal([]int{9, 5}, contents(&buf))
+}
+
+func TestRingBufferPeek(t *testing.T) {
+	assert := assert.New(t)
+
+	eq2 := func(v int, ok bool) func(expectedV int, expectedOk bool) {
+		return func(expectedV int, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedV, v)
+		}
+	}
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		eq2(buf.Peek())(0, false)
+	}
+
+	buf := ringbuffer.New[int](2)
+	eq2(buf.Peek())(0, false)
+	for i := 0; i < 5; i++ {
+		buf.Push(i)
+		buf.Push(i + 10)
+		eq2(buf.Peek())(i, true)
+		eq2(buf.Peek())(i, true)
+		assert.Equal(2, buf.Len())
+		buf.Pop()
+		eq2(buf.Peek())(i+10, true)
+		buf.Pop()
+		eq2(buf.Peek())(0, false)
+	}
+}
+
+func TestRingBufferBack(t *testing.T) {
+	assert := assert.New(t)
+
+	eq2 := func(v int, ok bool) func(expectedV int, expectedOk bool) {
+		return func(expectedV int, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedV, v)
+		}
+	}
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		eq2(buf.Back())(0, false)
+	}
+
+	buf := ringbuffer.New[int](2)
+	eq2(buf.Back())(0, false)
+	for i := 0; i < 5; i++ {
+		buf.Push(i)
+		eq2(buf.Back())(i, true)
+		buf.Push(i + 10)
+		eq2(buf.Back())(i+10, true)
+		assert.Equal(2, buf.Len())
+		buf.Pop()
+		eq2(buf.Back())(i+10, true)
+		buf.Pop()
+		eq2(buf.Back())(0, false)
+	}
+}
+
+func TestRingBufferAt(t *testing.T) {
+	assert := assert.New(t)
+
+	eq2 := func(v int, ok bool) func(expectedV int, expectedOk bool) {
+		return func(expectedV int, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedV, v)
+		}
+	}
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		eq2(buf.At(0))(0, false)
+	}
+
+	buf := ringbuffer.New[int](4)
+	// move cursors so that contents wrap around
+	for i := 0; i < 3; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	buf.PushMany([]int{1, 2, 3, 4})
+	for i := 0; i < 4; i++ {
+		eq2(buf.At(i))(i+1, true)
+	}
+	eq2(buf.At(-1))(0, false)
+	eq2(buf.At(4))(0, false)
+	buf.Pop()
+	eq2(buf.At(0))(2, true)
+	eq2(buf.At(3))(0, false)
+}
+
+func TestRingBufferSet(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		assert.Equal(false, buf.Set(0, 1))
+	}
+
+	buf := ringbuffer.New[int](4)
+	// move cursors so that contents wrap around
+	for i := 0; i < 3; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	buf.PushMany([]int{1, 2, 3, 4})
+	r := buf.ReadCursor()
+	for i := 0; i < 4; i++ {
+		assert.Equal(true, buf.Set(i, i*10))
+	}
+	assert.Equal(false, buf.Set(-1, 1))
+	assert.Equal(false, buf.Set(4, 1))
+	assert.Equal([]int{0, 10, 20, 30}, contents(&buf))
+
+	// read cursors observe the new values
+	v, ok := r.Next()
+	assert.Equal(true, ok)
+	assert.Equal(0, v)
+}
+
+func TestRingBufferDo(t *testing.T) {
+	assert := assert.New(t)
+
+	var out []int
+	collect := func(v int) { out = append(out, v) }
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		buf.Do(collect)
+		assert.Equal([]int(nil), out)
+	}
+
+	buf := ringbuffer.New[int](4)
+	for i := 0; i < 6; i++ {
+		out = nil
+		buf.Push(i)
+		buf.Push(i + 1)
+		buf.Push(i + 2)
+		buf.Do(collect)
+		assert.Equal([]int{i, i + 1, i + 2}, out)
+		buf.Drain()
+	}
+}
+
+func TestRingBufferValuesAll(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		assert.Equal([]int(nil), slices.Collect(buf.Values()))
+		for range buf.All() {
+			assert.Fail("no elements expected")
+		}
+	}
+
+	buf := ringbuffer.New[int](4)
+	// move cursors so that contents wrap around
+	for i := 0; i < 3; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	buf.PushMany([]int{1, 2, 3, 4})
+	assert.Equal([]int{1, 2, 3, 4}, slices.Collect(buf.Values()))
+	for i, v := range buf.All() {
+		assert.Equal(i+1, v)
+	}
+
+	// early break
+	var out []int
+	for v := range buf.Values() {
+		if v == 3 {
+			break
+		}
+		out = append(out, v)
+	}
+	assert.Equal([]int{1, 2}, out)
+	out = nil
+	for i, v := range buf.All() {
+		if i == 1 {
+			break
+		}
+		out = append(out, v)
+	}
+	assert.Equal([]int{1}, out)
+}
+
+func TestRingBufferBackward(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var buf ringbuffer.RingBuffer[int]
+		for range buf.Backward() {
+			assert.Fail("no elements expected")
+		}
+	}
+
+	buf := ringbuffer.New[int](4)
+	// move cursors so that contents wrap around
+	for i := 0; i < 3; i++ {
+		buf.Push(0)
+		buf.Pop()
+	}
+	buf.PushMany([]int{1, 2, 3, 4})
+	var idx, out []int
+	for i, v := range buf.Backward() {
+		idx = append(idx, i)
+		out = append(out, v)
+		if v == 2 {
+			break
+		}
+	}
+	assert.Equal([]int{3, 2, 1}, idx)
+	assert.Equal([]int{4, 3, 2}, out)
+}
+
 func ExampleRingBuffer() {
 	// Using ringbuffer structure alone without the "New" function is fairly useless, but it's valid.
 	var buf ringbuffer.RingBuffer[int]
@@ -147,6 +894,166 @@ func ExampleRingBuffer_Len() {
 	// Output: 0 1
 }
 
+func ExampleRingBuffer_Regions() {
+	b := ringbuffer.New[int](3)
+	b.Push(1)
+	b.Push(2)
+	b.Push(3)
+	b.Pop()
+	b.Pop()
+	b.Push(4)
+	b.Push(5)
+	x, y := b.Regions()
+	fmt.Println(x, y)
+	// Output: [3 4] [5]
+}
+
+func ExampleRingBuffer_MoveN() {
+	a := ringbuffer.New[int](5)
+	b := ringbuffer.New[int](5)
+	a.Push(1)
+	a.Push(2)
+	a.Push(3)
+	fmt.Println(a.MoveN(&b, 2), a.Len(), b.Len())
+	// Output: 2 1 2
+}
+
+func ExampleRingBuffer_Partition() {
+	b := ringbuffer.New[int](5)
+	for i := 1; i <= 5; i++ {
+		b.Push(i)
+	}
+	small, big := b.Partition(func(v int) bool { return v < 3 })
+	fmt.Println(small.Len(), big.Len())
+	// Output: 2 3
+}
+
+func ExampleRingBuffer_PushOverwrite() {
+	b := ringbuffer.New[int](3)
+	for i := 1; i <= 5; i++ {
+		if ev, ok := b.PushOverwrite(i); ok {
+			fmt.Println("evicted", ev)
+		}
+	}
+	x, y := b.Regions()
+	fmt.Println(x, y)
+	// Output:
+	// evicted 1
+	// evicted 2
+	// [3 4] [5]
+}
+
+func ExampleRingBuffer_PushMerge() {
+	latest := func(old, new string) string { return new }
+	b := ringbuffer.New[string](2)
+	b.PushMerge("a", latest)
+	b.PushMerge("b", latest)
+	b.PushMerge("c", latest)
+	x, y := b.Regions()
+	fmt.Println(x, y)
+	// Output: [a c] []
+}
+
+func ExampleRingBuffer_PushMany() {
+	b := ringbuffer.New[byte](4)
+	n := b.PushMany([]byte("hello"))
+	fmt.Println(n, b.Free())
+	// Output: 4 0
+}
+
+func ExampleRingBuffer_PopMany() {
+	b := ringbuffer.New[byte](8)
+	b.PushMany([]byte("packets"))
+	dst := make([]byte, 4)
+	n := b.PopMany(dst)
+	fmt.Println(string(dst[:n]), b.Len())
+	// Output: pack 3
+}
+
+func ExampleRingBuffer_Drain() {
+	queue := ringbuffer.New[string](4)
+	queue.Push("a")
+	queue.Push("b")
+	queue.Push("c")
+	fmt.Println(queue.Drain(), queue.Len())
+	// Output: [a b c] 0
+}
+
+func ExampleRingBuffer_AppendTo() {
+	b := ringbuffer.New[int](4)
+	b.PushMany([]int{1, 2, 3})
+	var snap []int
+	snap = b.AppendTo(snap[:0])
+	fmt.Println(snap, b.Len())
+	// Output: [1 2 3] 3
+}
+
+func ExampleRingBuffer_PushFront() {
+	jobs := ringbuffer.New[string](3)
+	jobs.Push("backup")
+	jobs.Push("report")
+	jobs.PushFront("urgent fix")
+	next, _ := jobs.Pop()
+	fmt.Println(next)
+	// Output: urgent fix
+}
+
+func ExampleRingBuffer_PopBack() {
+	undo := ringbuffer.New[string](3)
+	undo.Push("type a")
+	undo.Push("type b")
+	undo.Push("delete")
+	last, _ := undo.PopBack()
+	fmt.Println(last, undo.Len())
+	// Output: delete 2
+}
+
+func ExampleRingBuffer_Peek() {
+	b := ringbuffer.New[int](5)
+	b.Push(1)
+	b.Push(2)
+	v1, _ := b.Peek()
+	v2, _ := b.Pop()
+	fmt.Printf("%d %d %d\n", v1, v2, b.Len())
+	// Output: 1 1 1
+}
+
+func ExampleRingBuffer_Back() {
+	b := ringbuffer.New[int](5)
+	b.Push(1)
+	b.Push(2)
+	oldest, _ := b.Peek()
+	newest, _ := b.Back()
+	fmt.Printf("%d %d\n", oldest, newest)
+	// Output: 1 2
+}
+
+func ExampleNewFrom() {
+	b := ringbuffer.NewFrom(4, []string{"a", "b"})
+	v, _ := b.Pop()
+	fmt.Println(v, b.Len(), b.Cap())
+	// Output: a 1 4
+}
+
+func ExampleWrap() {
+	var slab [1024]byte
+	b := ringbuffer.Wrap(slab[:9])
+	b.PushMany([]byte("hi"))
+	fmt.Println(b.Cap(), string(slab[:2]))
+	// Output: 8 hi
+}
+
+func ExampleRingBuffer_Resize() {
+	b := ringbuffer.New[int](1)
+	b.Push(1)
+	fmt.Println(b.Push(2))
+	b.Resize(2)
+	fmt.Println(b.Push(2))
+	// Output:
+	// false
+	// true
+}
+
 func ExampleRingBuffer_Push() {
 	b := ringbuffer.New[int](5)
 	b.Push(1)
@@ -210,3 +1117,184 @@ func ExampleLen() {
 	fmt.Printf("%d %d\n", l1, l2)
 	// Output: 0 1
 }
+
+func ExampleRegions() {
+	var buf [4]int
+	var read int8
+	var write int8
+
+	ringbuffer.Push(buf[:], read, &write, 1)
+	ringbuffer.Push(buf[:], read, &write, 2)
+	a, b := ringbuffer.Regions(buf[:], read, write)
+	fmt.Println(a, b)
+	// Output: [1 2] []
+}
+
+func ExampleCompact() {
+	b := ringbuffer.New[string](5)
+	for _, v := range []string{"x=1", "y=1", "x=2"} {
+		b.Push(v)
+	}
+	ringbuffer.Compact(&b, func(v string) byte { return v[0] })
+	x, y := b.Regions()
+	fmt.Println(x, y)
+	// Output: [y=1 x=2] []
+}
+
+func ExamplePushMerge() {
+	var buf [3]int
+	var read uint8
+	var write uint8
+
+	sum := func(a, b int) int { return a + b }
+	for i := 1; i <= 4; i++ {
+		ringbuffer.PushMerge(buf[:], read, &write, i, sum)
+	}
+	v1, _ := ringbuffer.Pop(buf[:], &read, write)
+	v2, _ := ringbuffer.Pop(buf[:], &read, write)
+	fmt.Printf("%d %d\n", v1, v2)
+	// Output: 1 9
+}
+
+func ExamplePeek() {
+	var buf [5]int
+	var read int8
+	var write int8
+
+	ringbuffer.Push(buf[:], read, &write, 1)
+	v, ok := ringbuffer.Peek(buf[:], read, write)
+	fmt.Println(v, ok, ringbuffer.Len(buf[:], read, write))
+	// Output: 1 true 1
+}
+
+func ExampleRingBuffer_At() {
+	window := ringbuffer.New[float64](3)
+	window.PushMany([]float64{1.5, 2.5, 4})
+	sum := 0.0
+	for i := 0; i < window.Len(); i++ {
+		v, _ := window.At(i)
+		sum += v
+	}
+	fmt.Println(sum / float64(window.Len()))
+	// Output: 2.6666666666666665
+}
+
+func ExampleRingBuffer_Set() {
+	type event struct {
+		id    int
+		acked bool
+	}
+	events := ringbuffer.New[event](3)
+	events.Push(event{id: 1})
+	events.Push(event{id: 2})
+	e, _ := events.At(1)
+	e.acked = true
+	events.Set(1, e)
+	fmt.Println(events.Back())
+	// Output: {2 true} true
+}
+
+func ExampleRingBuffer_Do() {
+	b := ringbuffer.New[int](5)
+	b.PushMany([]int{3, 1, 4, 1, 5})
+	total := 0
+	b.Do(func(v int) { total += v })
+	fmt.Println(total)
+	// Output: 14
+}
+
+func ExampleRingBuffer_All() {
+	b := ringbuffer.New[string](3)
+	b.Push("a")
+	b.Push("b")
+	for i, v := range b.All() {
+		fmt.Println(i, v)
+	}
+	// Output:
+	// 0 a
+	// 1 b
+}
+
+func ExampleRingBuffer_Backward() {
+	log := ringbuffer.New[string](3)
+	log.Push("connected")
+	log.Push("logged in")
+	log.Push("opened file")
+	for _, v := range log.Backward() {
+		fmt.Println(v)
+	}
+	// Output:
+	// opened file
+	// logged in
+	// connected
+}
+
+func ExampleBack() {
+	var buf [5]int
+	var read int8
+	var write int8
+
+	ringbuffer.Push(buf[:], read, &write, 1)
+	ringbuffer.Push(buf[:], read, &write, 2)
+	v, ok := ringbuffer.Back(buf[:], read, write)
+	fmt.Println(v, ok)
+	// Output: 2 true
+}
+
+func ExamplePushOverwrite() {
+	var buf [3]int
+	var read int8
+	var write int8
+
+	ringbuffer.PushOverwrite(buf[:], &read, &write, 1)
+	ringbuffer.PushOverwrite(buf[:], &read, &write, 2)
+	ev, ok := ringbuffer.PushOverwrite(buf[:], &read, &write, 3)
+	fmt.Println(ev, ok, ringbuffer.Len(buf[:], read, write))
+	// Output: 1 true 2
+}
+
+func ExamplePushFront() {
+	var buf [5]int
+	var read int8
+	var write int8
+
+	ringbuffer.Push(buf[:], read, &write, 2)
+	ringbuffer.PushFront(buf[:], &read, write, 1)
+	v, _ := ringbuffer.Pop(buf[:], &read, write)
+	fmt.Println(v, ringbuffer.Len(buf[:], read, write))
+	// Output: 1 1
+}
+
+func ExamplePopBack() {
+	var buf [5]int
+	var read int8
+	var write int8
+
+	ringbuffer.Push(buf[:], read, &write, 1)
+	ringbuffer.Push(buf[:], read, &write, 2)
+	v, _ := ringbuffer.PopBack(buf[:], read, &write)
+	fmt.Println(v, ringbuffer.Len(buf[:], read, write))
+	// Output: 2 1
+}
+
+func ExampleFree() {
+	var buf [5]int
+	var read int8
+	var write int8
+
+	ringbuffer.Push(buf[:], read, &write, 1)
+	fmt.Println(ringbuffer.Free(buf[:], read, write))
+	// Output: 3
+}
+
+func ExampleAt() {
+	var buf [5]int
+	var read int8
+	var write int8
+
+	ringbuffer.Push(buf[:], read, &write, 1)
+	ringbuffer.Push(buf[:], read, &write, 2)
+	v, _ := ringbuffer.At(buf[:], read, write, 1)
+	fmt.Println(v)
+	// Output: 2
+}
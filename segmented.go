@@ -0,0 +1,164 @@
+package ringbuffer
+
+import (
+	"slices"
+)
+
+// Fixed length FIFO ring buffer composed of equally sized segments arranged in a circle.
+//
+// Capacity can be raised or lowered at runtime by adding or removing whole segments. Existing elements stay where
+// they are, new segments are spliced into the free gap between the write and the read positions.
+//
+// Implementation detail: unlike RingBuffer, this one tracks an explicit element count, all segment space is usable.
+type SegmentedRing[T any] struct {
+	segSize  int
+	segments [][]T
+	head     int // absolute slot index of the oldest element
+	count    int
+}
+
+// Create a new segmented ring buffer with the given number of segments, each holding segmentSize elements.
+func NewSegmentedRing[T any](segmentSize, segments int) SegmentedRing[T] {
+	r := SegmentedRing[T]{segSize: segmentSize}
+	if segmentSize >= 1 {
+		r.Grow(segments)
+	}
+	return r
+}
+
+// How many elements a buffer can store?
+func (r SegmentedRing[T]) Cap() int {
+	return r.segSize * len(r.segments)
+}
+
+// How many elements are currently stored in the buffer?
+func (r SegmentedRing[T]) Len() int {
+	return r.count
+}
+
+// How many segments are currently in the ring?
+func (r SegmentedRing[T]) Segments() int {
+	return len(r.segments)
+}
+
+func (r *SegmentedRing[T]) slot(i int) *T {
+	return &r.segments[i/r.segSize][i%r.segSize]
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (r *SegmentedRing[T]) Push(v T) bool {
+	c := r.Cap()
+	if r.count == c {
+		return false
+	}
+	*r.slot((r.head + r.count) % c) = v
+	r.count++
+	return true
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (r *SegmentedRing[T]) Pop() (T, bool) {
+	var def T
+	if r.count == 0 {
+		return def, false
+	}
+	p := r.slot(r.head)
+	v := *p
+	*p = def
+	r.head = (r.head + 1) % r.Cap()
+	r.count--
+	return v, true
+}
+
+// Add n empty segments to the ring, raising its capacity by n*segmentSize.
+//
+// Elements are not moved, except when both read and write positions lie inside the same segment with no segment
+// boundary in between. In that case up to segmentSize-1 elements are copied into the new space.
+func (r *SegmentedRing[T]) Grow(n int) {
+	if n <= 0 || r.segSize <= 0 {
+		return
+	}
+	fresh := make([][]T, n)
+	for i := range fresh {
+		fresh[i] = make([]T, r.segSize)
+	}
+	if r.count == 0 {
+		r.segments = append(r.segments, fresh...)
+		r.head = 0
+		return
+	}
+
+	c := r.Cap()
+	free := c - r.count
+	tail := (r.head + r.count) % c
+	boundary := (tail + r.segSize - 1) / r.segSize * r.segSize
+	if boundary-tail <= free {
+		// There is a segment boundary inside the free gap (or at one of its ends), splice new segments there.
+		j := boundary / r.segSize % len(r.segments)
+		r.insertSegments(j, fresh)
+		if r.head >= j*r.segSize {
+			r.head += n * r.segSize
+		}
+		return
+	}
+
+	// The free gap lies strictly inside one segment, head follows tail in it. Splice new segments after that
+	// segment and move the elements between head and the segment end into the last new segment.
+	j := tail/r.segSize + 1
+	end := j * r.segSize
+	last := fresh[n-1]
+	for i := r.head; i < end; i++ {
+		p := r.slot(i)
+		last[i%r.segSize] = *p
+		var def T
+		*p = def
+	}
+	r.insertSegments(j, fresh)
+	r.head += n * r.segSize
+}
+
+func (r *SegmentedRing[T]) insertSegments(j int, fresh [][]T) {
+	r.segments = slices.Insert(r.segments, j, fresh...)
+}
+
+// Remove up to n segments which contain no elements, lowering capacity by segmentSize for each removed segment.
+//
+// Returns how many segments were actually removed.
+func (r *SegmentedRing[T]) Shrink(n int) int {
+	if n <= 0 || len(r.segments) == 0 {
+		return 0
+	}
+	if r.count == 0 {
+		if n > len(r.segments) {
+			n = len(r.segments)
+		}
+		r.segments = r.segments[:len(r.segments)-n]
+		r.head = 0
+		return n
+	}
+
+	// Collect segments fully covered by the free gap. They are consecutive in circle order.
+	c := r.Cap()
+	free := c - r.count
+	tail := (r.head + r.count) % c
+	first := (tail + r.segSize - 1) / r.segSize * r.segSize
+	var removable []int
+	for d := first - tail; d+r.segSize <= free && len(removable) < n; d += r.segSize {
+		removable = append(removable, (tail+d)%c/r.segSize)
+	}
+
+	// Remove from the highest index down, so that lower indices stay valid.
+	slices.Sort(removable)
+	for i := len(removable) - 1; i >= 0; i-- {
+		j := removable[i]
+		r.segments = slices.Delete(r.segments, j, j+1)
+		if r.head >= (j+1)*r.segSize {
+			r.head -= r.segSize
+		}
+	}
+	return len(removable)
+}
@@ -0,0 +1,101 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestSegmentedRing(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var r ringbuffer.SegmentedRing[int]
+		assert.Equal(false, r.Push(1))
+		assert.Equal(0, r.Cap())
+		assert.Equal(0, r.Len())
+		r.Grow(1)
+		assert.Equal(0, r.Cap())
+	}
+
+	r := ringbuffer.NewSegmentedRing[int](3, 2)
+	assert.Equal(6, r.Cap())
+	for i := 0; i < 6; i++ {
+		assert.Equal(true, r.Push(i))
+	}
+	assert.Equal(false, r.Push(6))
+	assert.Equal(0, r.Shrink(1))
+
+	// full buffer with cursors on a segment boundary
+	r.Grow(1)
+	assert.Equal(9, r.Cap())
+	assert.Equal(true, r.Push(6))
+
+	// cursors inside the same segment
+	for i := 0; i < 4; i++ {
+		v, _ := r.Pop()
+		assert.Equal(i, v)
+	}
+	assert.Equal(true, r.Push(7))
+	r.Grow(1)
+	assert.Equal(12, r.Cap())
+	assert.Equal(2, r.Shrink(2))
+	assert.Equal(6, r.Cap())
+	for i := 4; i < 8; i++ {
+		v, ok := r.Pop()
+		assert.Equal(true, ok)
+		assert.Equal(i, v)
+	}
+	_, ok := r.Pop()
+	assert.Equal(false, ok)
+	assert.Equal(2, r.Shrink(5))
+	assert.Equal(0, r.Cap())
+}
+
+func TestSegmentedRingRandom(t *testing.T) {
+	assert := assert.New(t)
+	rnd := rand.New(rand.NewSource(1))
+	r := ringbuffer.NewSegmentedRing[int](4, 1)
+	var model []int
+	next := 0
+	for i := 0; i < 20000; i++ {
+		switch rnd.Intn(8) {
+		case 0:
+			r.Grow(1 + rnd.Intn(2))
+		case 1:
+			r.Shrink(1 + rnd.Intn(2))
+		case 2, 3, 4:
+			free := len(model) < r.Cap()
+			ok := r.Push(next)
+			assert.Equal(free, ok)
+			if ok {
+				model = append(model, next)
+			}
+			next++
+		default:
+			v, ok := r.Pop()
+			assert.Equal(len(model) > 0, ok)
+			if ok {
+				assert.Equal(model[0], v)
+				model = model[1:]
+			}
+		}
+		assert.Equal(len(model), r.Len())
+		assert.Equal(r.Segments()*4, r.Cap())
+		assert.LessOrEqual(r.Len(), r.Cap())
+	}
+}
+
+func ExampleSegmentedRing_Grow() {
+	r := ringbuffer.NewSegmentedRing[int](2, 1)
+	r.Push(1)
+	r.Push(2)
+	fmt.Println(r.Push(3))
+	r.Grow(1)
+	fmt.Println(r.Push(3), r.Cap())
+	// Output:
+	// false
+	// true 4
+}
@@ -0,0 +1,58 @@
+package ringbuffer
+
+import (
+	"context"
+	"math/rand/v2"
+)
+
+// Pop an element from whichever of several buffers has one, waiting until any of them does.
+//
+// Buffers are tried starting at a random one, so that a busy buffer can't starve the others. Waiting works without
+// reflection or helper goroutines: the call registers a private signal with every buffer, fired when a buffer goes
+// from empty to non-empty. Returns the index of the buffer, the popped element and nil on success. Returns -1,
+// default value and ctx.Err() if ctx is done first.
+func Select[T any](ctx context.Context, bufs ...*Sync[T]) (int, T, error) {
+	if i, v, ok := trySelect(bufs); ok {
+		return i, v, nil
+	}
+	ch := make(chan struct{}, 1)
+	for _, b := range bufs {
+		b.mu.Lock()
+		b.notify.watch(ch)
+		b.mu.Unlock()
+	}
+	defer func() {
+		for _, b := range bufs {
+			b.mu.Lock()
+			b.notify.unwatch(ch)
+			b.mu.Unlock()
+		}
+	}()
+	for {
+		// registered before trying, a push after an unsuccessful try fires the signal
+		if i, v, ok := trySelect(bufs); ok {
+			return i, v, nil
+		}
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			var def T
+			return -1, def, ctx.Err()
+		}
+	}
+}
+
+func trySelect[T any](bufs []*Sync[T]) (int, T, bool) {
+	var def T
+	if len(bufs) == 0 {
+		return -1, def, false
+	}
+	start := rand.IntN(len(bufs))
+	for k := range bufs {
+		i := (start + k) % len(bufs)
+		if v, ok := bufs[i].Pop(); ok {
+			return i, v, true
+		}
+	}
+	return -1, def, false
+}
@@ -0,0 +1,88 @@
+package ringbuffer_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSelect(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	i, _, err := ringbuffer.Select[int](ctx)
+	assert.Equal(-1, i)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	a := ringbuffer.NewSync[int](4)
+	b := ringbuffer.NewSync[int](4)
+	b.Push(7)
+	i, v, err := ringbuffer.Select(context.Background(), a, b)
+	assert.NoError(err)
+	assert.Equal(1, i)
+	assert.Equal(7, v)
+
+	// waits for a push to any of the buffers
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		a.Push(3)
+	}()
+	i, v, err = ringbuffer.Select(context.Background(), a, b)
+	assert.NoError(err)
+	assert.Equal(0, i)
+	assert.Equal(3, v)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, _, err = ringbuffer.Select(ctx, a, b)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestSelectConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	const n = 1000
+	bufs := []*ringbuffer.Sync[int]{ringbuffer.NewSync[int](8), ringbuffer.NewSync[int](8), ringbuffer.NewSync[int](8)}
+	var wg sync.WaitGroup
+	for _, b := range bufs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= n; {
+				if b.Push(i) {
+					i++
+				} else {
+					time.Sleep(time.Microsecond)
+				}
+			}
+		}()
+	}
+
+	// every element arrives once, in FIFO order per buffer
+	last := make([]int, len(bufs))
+	for k := 0; k < len(bufs)*n; k++ {
+		i, v, err := ringbuffer.Select(context.Background(), bufs...)
+		assert.NoError(err)
+		if v != last[i]+1 {
+			assert.Equal(last[i]+1, v)
+			return
+		}
+		last[i] = v
+	}
+	wg.Wait()
+	assert.Equal([]int{n, n, n}, last)
+}
+
+func ExampleSelect() {
+	orders := ringbuffer.NewSync[string](8)
+	alerts := ringbuffer.NewSync[string](8)
+	alerts.Push("disk full")
+	i, v, _ := ringbuffer.Select(context.Background(), orders, alerts)
+	fmt.Println(i, v)
+	// Output: 1 disk full
+}
@@ -0,0 +1,154 @@
+package ringbuffer
+
+import (
+	"math/bits"
+	"runtime"
+	"sync/atomic"
+)
+
+// Lock-free ring of preallocated slots with sequence numbers, in the style of the LMAX Disruptor.
+//
+// Producers claim a sequence number, fill the slot for it in place and publish it. Consumers process published
+// sequences in order and release them when done. A consumer may depend on other consumers, seeing a sequence only
+// after all of them have released it, which allows building pipelines where every stage works on the same slots
+// without copying. Producers gate on the slowest consumer, a slot is not reused before every consumer has released
+// it. Without consumers nothing gates producers, and old slots are overwritten.
+//
+// Any number of goroutines may claim and publish. Each consumer must only be used from one goroutine. All consumers
+// must be added before the first sequence is claimed.
+type Sequenced[T any] struct {
+	_         [cacheLine]byte
+	claim     atomic.Uint64 // next sequence to claim
+	_         [cacheLine - 8]byte
+	gate      atomic.Uint64 // cached minimum of consumer positions
+	_         [cacheLine - 8]byte
+	mask      uint64
+	buffer    []T
+	published []atomic.Uint64 // sequence+1 of the element published in each slot
+	consumers []*Consumer[T]
+}
+
+// Consumer of a Sequenced ring, tracking which sequences it has processed.
+type Consumer[T any] struct {
+	_    [cacheLine]byte
+	next atomic.Uint64 // sequences below next are released
+	_    [cacheLine - 8]byte
+	s    *Sequenced[T]
+	deps []*Consumer[T]
+}
+
+// Create a new ring of at least capacity slots, rounded up to the next power of two.
+func NewSequenced[T any](capacity int) *Sequenced[T] {
+	n := uint64(1) << bits.Len(uint(max(capacity, 1)-1))
+	return &Sequenced[T]{
+		mask:      n - 1,
+		buffer:    make([]T, n),
+		published: make([]atomic.Uint64, n),
+	}
+}
+
+// How many slots a ring has?
+func (s *Sequenced[T]) Cap() int {
+	return len(s.buffer)
+}
+
+// Add a consumer which processes sequences after all of deps have released them, or as soon as they are published
+// if there are no deps. Panics if a sequence was already claimed.
+func (s *Sequenced[T]) AddConsumer(deps ...*Consumer[T]) *Consumer[T] {
+	if s.claim.Load() != 0 {
+		panic("ringbuffer: consumer added after claiming started")
+	}
+	c := &Consumer[T]{s: s, deps: deps}
+	s.consumers = append(s.consumers, c)
+	return c
+}
+
+// Claim the next sequence, waiting until its slot was released by all consumers.
+func (s *Sequenced[T]) Claim() uint64 {
+	seq := s.claim.Add(1) - 1
+	for !s.free(seq) {
+		runtime.Gosched()
+	}
+	return seq
+}
+
+// Try to claim the next sequence.
+//
+// Returns the sequence and true on success. Returns 0 and false if all slots are in use and claim failed.
+func (s *Sequenced[T]) TryClaim() (uint64, bool) {
+	for {
+		seq := s.claim.Load()
+		if !s.free(seq) {
+			return 0, false
+		}
+		if s.claim.CompareAndSwap(seq, seq+1) {
+			return seq, true
+		}
+	}
+}
+
+// Is the slot for seq released by all consumers?
+func (s *Sequenced[T]) free(seq uint64) bool {
+	size := uint64(len(s.buffer))
+	if seq < size+s.gate.Load() || len(s.consumers) == 0 {
+		return true
+	}
+	gate := s.consumers[0].next.Load()
+	for _, c := range s.consumers[1:] {
+		gate = min(gate, c.next.Load())
+	}
+	s.gate.Store(gate)
+	return seq < size+gate
+}
+
+// Get the slot for a sequence. Producers fill claimed slots before publishing, consumers read or update them before
+// releasing.
+func (s *Sequenced[T]) Slot(seq uint64) *T {
+	return &s.buffer[seq&s.mask]
+}
+
+// Make a claimed sequence visible to consumers.
+func (s *Sequenced[T]) Publish(seq uint64) {
+	s.published[seq&s.mask].Store(seq + 1)
+}
+
+// Get the next sequence this consumer has not released yet.
+func (c *Consumer[T]) Next() uint64 {
+	return c.next.Load()
+}
+
+// Get the end of the run of sequences ready for this consumer, starting at Next. Does not wait.
+//
+// Sequences from Next up to, but not including, the returned one may be processed.
+func (c *Consumer[T]) Available() uint64 {
+	next := c.next.Load()
+	if len(c.deps) > 0 {
+		end := c.deps[0].next.Load()
+		for _, d := range c.deps[1:] {
+			end = min(end, d.next.Load())
+		}
+		return end
+	}
+	end := next
+	for end-next < uint64(len(c.s.buffer)) && c.s.published[end&c.s.mask].Load() == end+1 {
+		end++
+	}
+	return end
+}
+
+// Wait until at least one sequence is ready for this consumer and get the end of the ready run, see Available.
+func (c *Consumer[T]) Wait() uint64 {
+	next := c.next.Load()
+	for {
+		if end := c.Available(); end > next {
+			return end
+		}
+		runtime.Gosched()
+	}
+}
+
+// Release all sequences below end, marking them processed. Their slots become visible to dependent consumers and
+// eventually reusable by producers.
+func (c *Consumer[T]) Release(end uint64) {
+	c.next.Store(end)
+}
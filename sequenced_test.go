@@ -0,0 +1,113 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func TestSequenced(t *testing.T) {
+	assert := assert.New(t)
+
+	s := ringbuffer.NewSequenced[int](3)
+	assert.Equal(4, s.Cap())
+	c := s.AddConsumer()
+	assert.Equal(uint64(0), c.Available())
+	for i := 0; i < 4; i++ {
+		seq, ok := s.TryClaim()
+		assert.Equal(true, ok)
+		assert.Equal(uint64(i), seq)
+		*s.Slot(seq) = i * 10
+	}
+	_, ok := s.TryClaim()
+	assert.Equal(false, ok)
+
+	// only a contiguous run of published sequences is available
+	s.Publish(0)
+	s.Publish(2)
+	assert.Equal(uint64(1), c.Available())
+	s.Publish(1)
+	s.Publish(3)
+	assert.Equal(uint64(4), c.Wait())
+	assert.Equal(20, *s.Slot(2))
+
+	c.Release(2)
+	assert.Equal(uint64(2), c.Next())
+	seq, ok := s.TryClaim()
+	assert.Equal(true, ok)
+	assert.Equal(uint64(4), seq)
+	s.Publish(seq)
+	assert.Equal(uint64(5), c.Available())
+	assert.Panics(func() { s.AddConsumer() })
+
+	// no consumers, no gating
+	s = ringbuffer.NewSequenced[int](0)
+	assert.Equal(1, s.Cap())
+	for i := 0; i < 3; i++ {
+		_, ok := s.TryClaim()
+		assert.Equal(true, ok)
+	}
+}
+
+func TestSequencedPipeline(t *testing.T) {
+	assert := assert.New(t)
+
+	const producers = 4
+	const perProducer = 5000
+	s := ringbuffer.NewSequenced[int](16)
+	double := s.AddConsumer()
+	sum := s.AddConsumer(double)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= perProducer; i++ {
+				seq := s.Claim()
+				*s.Slot(seq) = i
+				s.Publish(seq)
+			}
+		}()
+	}
+	go func() {
+		for double.Next() < producers*perProducer {
+			end := double.Wait()
+			for seq := double.Next(); seq < end; seq++ {
+				*s.Slot(seq) *= 2
+			}
+			double.Release(end)
+		}
+	}()
+
+	// the second stage only sees slots already updated by the first one
+	total := 0
+	for sum.Next() < producers*perProducer {
+		end := sum.Wait()
+		for seq := sum.Next(); seq < end; seq++ {
+			total += *s.Slot(seq)
+		}
+		sum.Release(end)
+	}
+	wg.Wait()
+	assert.Equal(producers*perProducer*(perProducer+1), total)
+}
+
+func ExampleSequenced() {
+	s := ringbuffer.NewSequenced[string](8)
+	c := s.AddConsumer()
+	for _, v := range []string{"a", "b", "c"} {
+		seq := s.Claim()
+		*s.Slot(seq) = v
+		s.Publish(seq)
+	}
+	end := c.Wait()
+	for seq := c.Next(); seq < end; seq++ {
+		fmt.Print(*s.Slot(seq), " ")
+	}
+	c.Release(end)
+	fmt.Println()
+	// Output: a b c
+}
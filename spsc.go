@@ -0,0 +1,81 @@
+package ringbuffer
+
+import (
+	"sync/atomic"
+)
+
+// Assumed CPU cache line size, used for padding fields written by different goroutines.
+const cacheLine = 64
+
+// Lock-free fixed length FIFO ring buffer for exactly one producer and one consumer goroutine.
+//
+// Push may be called by a single producer and Pop by a single consumer concurrently with each other, without locks.
+// The producer publishes elements by storing the write position after the element, the consumer releases slots by
+// storing the read position after loading the element. Go atomics are sequentially consistent, which is stronger than
+// the acquire/release ordering needed. Each side also keeps a cached copy of the other side's position, to avoid
+// touching a shared cache line on every operation. Positions are free running counters, all slots are used.
+type SPSC[T any] struct {
+	_ [cacheLine]byte
+
+	write     atomic.Uint64
+	readCache uint64 // producer's view of read
+	_         [cacheLine - 16]byte
+
+	read       atomic.Uint64
+	writeCache uint64 // consumer's view of write
+	_          [cacheLine - 16]byte
+
+	buffer []T
+}
+
+// Create a new buffer which can store capacity elements.
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	return &SPSC[T]{buffer: make([]T, max(capacity, 0))}
+}
+
+// How many elements a buffer can store?
+func (b *SPSC[T]) Cap() int {
+	return len(b.buffer)
+}
+
+// How many elements are currently stored in the buffer? The value may be stale by the time it is returned.
+func (b *SPSC[T]) Len() int {
+	r := b.read.Load()
+	return int(b.write.Load() - r)
+}
+
+// Push a new element to the buffer. Must only be called from the producer goroutine.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *SPSC[T]) Push(v T) bool {
+	w := b.write.Load()
+	if w-b.readCache == uint64(len(b.buffer)) {
+		b.readCache = b.read.Load()
+		if w-b.readCache == uint64(len(b.buffer)) {
+			return false // no more space
+		}
+	}
+	b.buffer[w%uint64(len(b.buffer))] = v
+	b.write.Store(w + 1)
+	return true
+}
+
+// Try to pop an element from the buffer. Must only be called from the consumer goroutine.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *SPSC[T]) Pop() (T, bool) {
+	r := b.read.Load()
+	if r == b.writeCache {
+		b.writeCache = b.write.Load()
+		if r == b.writeCache {
+			var def T
+			return def, false
+		}
+	}
+	var def T
+	i := r % uint64(len(b.buffer))
+	v := b.buffer[i]
+	b.buffer[i] = def
+	b.read.Store(r + 1)
+	return v, true
+}
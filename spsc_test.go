@@ -0,0 +1,91 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"testing"
+)
+
+func TestSPSC(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		b := ringbuffer.NewSPSC[int](0)
+		assert.Equal(false, b.Push(1))
+		_, ok := b.Pop()
+		assert.Equal(false, ok)
+	}
+
+	b := ringbuffer.NewSPSC[int](3)
+	assert.Equal(3, b.Cap())
+	for i := 0; i < 5; i++ {
+		assert.Equal(true, b.Push(1))
+		assert.Equal(true, b.Push(2))
+		assert.Equal(true, b.Push(3))
+		assert.Equal(false, b.Push(4))
+		assert.Equal(3, b.Len())
+		for j := 1; j <= 3; j++ {
+			v, ok := b.Pop()
+			assert.Equal(true, ok)
+			assert.Equal(j, v)
+		}
+		_, ok := b.Pop()
+		assert.Equal(false, ok)
+	}
+}
+
+func TestSPSCConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	const n = 100000
+	b := ringbuffer.NewSPSC[int](64)
+	go func() {
+		for i := 0; i < n; {
+			if b.Push(i) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	// elements arrive in order, none lost or duplicated
+	for i := 0; i < n; {
+		v, ok := b.Pop()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		if v != i {
+			assert.Equal(i, v)
+			return
+		}
+		i++
+	}
+	assert.Equal(0, b.Len())
+}
+
+func ExampleSPSC() {
+	b := ringbuffer.NewSPSC[string](4)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, v := range []string{"a", "b", "c"} {
+			for !b.Push(v) {
+				runtime.Gosched()
+			}
+		}
+	}()
+	<-done
+	for {
+		v, ok := b.Pop()
+		if !ok {
+			break
+		}
+		fmt.Print(v)
+	}
+	fmt.Println()
+	// Output: abc
+}
@@ -0,0 +1,277 @@
+package ringbuffer
+
+import (
+	"iter"
+	"sync"
+)
+
+// Fixed length FIFO ring buffer safe for concurrent use, every method holds a mutex for its duration.
+//
+// Methods mirror RingBuffer. Operations are atomic individually, combining them (e.g. Len followed by Pop) is not.
+// Iterators work on a snapshot. Methods handing out references into the storage (Regions, Cursor, ReadCursor) are
+// left out, and so is MoveN, which would have to lock two buffers; use Do or Clone instead. Must not be copied after
+// first use.
+type Sync[T any] struct {
+	mu       sync.Mutex
+	buf      RingBuffer[T]
+	wasEmpty bool
+	wasFull  bool
+	notify   notifier
+	mirrors  []*Mirror[T]
+}
+
+// Create a new buffer which can store capacity elements, configured by options. See NewWithOptions.
+func NewSync[T any](capacity int, opts ...Option) *Sync[T] {
+	return &Sync[T]{buf: NewWithOptions[T](capacity, opts...)}
+}
+
+// How many elements a buffer can store?
+func (b *Sync[T]) Cap() int {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Cap()
+}
+
+// How many elements are currently stored in the buffer?
+func (b *Sync[T]) Len() int {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Len()
+}
+
+// How many more elements can be pushed before the buffer is full?
+func (b *Sync[T]) Free() int {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Free()
+}
+
+// Are there no elements in the buffer?
+func (b *Sync[T]) IsEmpty() bool {
+	b.lock()
+	defer b.unlock()
+	return b.buf.IsEmpty()
+}
+
+// Is there no free space left in the buffer?
+func (b *Sync[T]) IsFull() bool {
+	b.lock()
+	defer b.unlock()
+	return b.buf.IsFull()
+}
+
+// Push a new element to the buffer, see RingBuffer.Push.
+func (b *Sync[T]) Push(v T) bool {
+	b.lock()
+	defer b.unlock()
+	if !b.buf.Push(v) {
+		return false
+	}
+	tap(b.mirrors, v)
+	return true
+}
+
+// Push a new element to the buffer, evicting the oldest one if full. See RingBuffer.PushOverwrite.
+func (b *Sync[T]) PushOverwrite(v T) (T, bool) {
+	b.lock()
+	defer b.unlock()
+	if b.buf.canOverwrite() {
+		tap(b.mirrors, v)
+	}
+	return b.buf.PushOverwrite(v)
+}
+
+// Push a new element to the buffer, merging it with the newest one if full. See RingBuffer.PushMerge.
+func (b *Sync[T]) PushMerge(v T, merge func(old, new T) T) bool {
+	b.lock()
+	defer b.unlock()
+	if b.buf.Cap() > 0 {
+		tap(b.mirrors, v)
+	}
+	return b.buf.PushMerge(v, merge)
+}
+
+// Push a new element in front of the oldest one, so that it is popped next. See RingBuffer.PushFront.
+func (b *Sync[T]) PushFront(v T) bool {
+	b.lock()
+	defer b.unlock()
+	if !b.buf.PushFront(v) {
+		return false
+	}
+	tap(b.mirrors, v)
+	return true
+}
+
+// Push as many elements from vs as possible to the buffer, in order. See RingBuffer.PushMany.
+func (b *Sync[T]) PushMany(vs []T) int {
+	b.lock()
+	defer b.unlock()
+	n := b.buf.PushMany(vs)
+	tap(b.mirrors, vs[:n]...)
+	return n
+}
+
+// Try to pop an element from the buffer, see RingBuffer.Pop.
+func (b *Sync[T]) Pop() (T, bool) {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Pop()
+}
+
+// Try to pop the most recently pushed element from the buffer, see RingBuffer.PopBack.
+func (b *Sync[T]) PopBack() (T, bool) {
+	b.lock()
+	defer b.unlock()
+	return b.buf.PopBack()
+}
+
+// Pop up to len(dst) elements from the buffer into dst, in FIFO order. See RingBuffer.PopMany.
+func (b *Sync[T]) PopMany(dst []T) int {
+	b.lock()
+	defer b.unlock()
+	return b.buf.PopMany(dst)
+}
+
+// Pop all elements from the buffer, returning them in FIFO order as a new slice.
+func (b *Sync[T]) Drain() []T {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Drain()
+}
+
+// Look at the oldest element without removing it from the buffer, see RingBuffer.Peek.
+func (b *Sync[T]) Peek() (T, bool) {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Peek()
+}
+
+// Look at the most recently pushed element without removing it from the buffer, see RingBuffer.Back.
+func (b *Sync[T]) Back() (T, bool) {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Back()
+}
+
+// Get the element at logical index i, 0 being the oldest element. See RingBuffer.At.
+func (b *Sync[T]) At(i int) (T, bool) {
+	b.lock()
+	defer b.unlock()
+	return b.buf.At(i)
+}
+
+// Replace the element at logical index i, 0 being the oldest element. See RingBuffer.Set.
+func (b *Sync[T]) Set(i int, v T) bool {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Set(i, v)
+}
+
+// What is the sequence number of the oldest element? See RingBuffer.Seq.
+func (b *Sync[T]) Seq() uint64 {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Seq()
+}
+
+// Protect the element with sequence number seq from eviction, see RingBuffer.Pin.
+func (b *Sync[T]) Pin(seq uint64) bool {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Pin(seq)
+}
+
+// Remove the protection added by Pin, see RingBuffer.Unpin.
+func (b *Sync[T]) Unpin(seq uint64) bool {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Unpin(seq)
+}
+
+// How many stored elements are pinned?
+func (b *Sync[T]) Pinned() int {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Pinned()
+}
+
+// Append stored elements in FIFO order to dst and return the extended slice.
+func (b *Sync[T]) AppendTo(dst []T) []T {
+	b.lock()
+	defer b.unlock()
+	return b.buf.AppendTo(dst)
+}
+
+// Call f for each stored element in FIFO order, while holding the lock. The buffer must not be used by f.
+func (b *Sync[T]) Do(f func(v T)) {
+	b.lock()
+	defer b.unlock()
+	b.buf.Do(f)
+}
+
+// Iterate over a snapshot of stored elements in FIFO order, taken under the lock when iteration starts.
+func (b *Sync[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range b.AppendTo(nil) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over logical indices and a snapshot of stored elements in FIFO order, taken under the lock when iteration
+// starts.
+func (b *Sync[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range b.AppendTo(nil) {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Iterate over logical indices and a snapshot of stored elements from the newest to the oldest, taken under the lock
+// when iteration starts.
+func (b *Sync[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		vs := b.AppendTo(nil)
+		for i := len(vs) - 1; i >= 0; i-- {
+			if !yield(i, vs[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Split stored elements into two new unsynchronized buffers, see RingBuffer.Partition.
+func (b *Sync[T]) Partition(pred func(T) bool) (matched, rest RingBuffer[T]) {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Partition(pred)
+}
+
+// Change the capacity of the buffer, see RingBuffer.Resize.
+func (b *Sync[T]) Resize(capacity int) bool {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Resize(capacity)
+}
+
+// Make an unsynchronized snapshot of the buffer, with its own backing storage.
+func (b *Sync[T]) Clone() RingBuffer[T] {
+	b.lock()
+	defer b.unlock()
+	return b.buf.Clone()
+}
+
+func (b *Sync[T]) lock() {
+	b.mu.Lock()
+	b.wasEmpty, b.wasFull = b.buf.IsEmpty(), b.buf.IsFull()
+}
+
+func (b *Sync[T]) unlock() {
+	b.notify.update(b.wasEmpty, b.wasFull, b.buf.IsEmpty(), b.buf.IsFull())
+	b.mu.Unlock()
+}
@@ -0,0 +1,113 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestSync(t *testing.T) {
+	assert := assert.New(t)
+
+	b := ringbuffer.NewSync[int](16)
+	const producers = 4
+	const perProducer = 1000
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; {
+				n := 0
+				if i%10 == 0 {
+					n = b.PushMany([]int{1, 1})
+				} else if b.Push(1) {
+					n = 1
+				}
+				if n == 0 {
+					runtime.Gosched()
+				}
+				i += n
+			}
+		}()
+	}
+
+	sum := 0
+	dst := make([]int, 4)
+	for sum < producers*perProducer {
+		if v, ok := b.Pop(); ok {
+			sum += v
+		}
+		n := b.PopMany(dst)
+		for _, v := range dst[:n] {
+			sum += v
+		}
+		if n == 0 {
+			runtime.Gosched()
+		}
+	}
+	wg.Wait()
+	assert.Equal(producers*perProducer, sum)
+	assert.Equal(true, b.IsEmpty())
+
+	b.PushMany([]int{1, 2, 3})
+	assert.Equal(true, b.Set(1, 20))
+	snap := b.Clone()
+	b.Pop()
+	assert.Equal(3, snap.Len())
+	assert.Equal([]int{20, 3}, b.AppendTo(nil))
+	assert.Equal(true, b.Resize(2))
+	assert.Equal(true, b.IsFull())
+	assert.Equal([]int{20, 3}, b.Drain())
+
+	add := func(old, new int) int { return old + new }
+	b.Push(1)
+	assert.Equal(true, b.PushMerge(2, add))
+	assert.Equal(false, b.PushMerge(4, add))
+	odd, even := b.Partition(func(v int) bool { return v%2 == 1 })
+	assert.Equal([]int{1}, odd.Drain())
+	assert.Equal([]int{6}, even.Drain())
+	assert.Equal(2, b.Len())
+
+	// iterators work on a snapshot, so the buffer may be modified while iterating
+	b = ringbuffer.NewSync[int](4)
+	b.PushMany([]int{1, 2, 3})
+	var got []int
+	for v := range b.Values() {
+		got = append(got, v)
+		b.Pop()
+	}
+	assert.Equal([]int{1, 2, 3}, got)
+	assert.Equal(0, b.Len())
+	b.PushMany([]int{4, 5})
+	got = nil
+	for i, v := range b.All() {
+		got = append(got, i, v)
+	}
+	for i, v := range b.Backward() {
+		got = append(got, i, v)
+		break
+	}
+	assert.Equal([]int{0, 4, 1, 5, 1, 5}, got)
+}
+
+func ExampleSync() {
+	b := ringbuffer.NewSync[int](8)
+	var wg sync.WaitGroup
+	for i := 1; i <= 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.Push(i)
+		}()
+	}
+	wg.Wait()
+	sum := 0
+	b.Do(func(v int) { sum += v })
+	fmt.Println(b.Len(), sum)
+	// Output: 3 6
+}
@@ -0,0 +1,49 @@
+package ringbuffer
+
+// Fixed length FIFO ring buffer which pairs every element with caller-defined metadata.
+//
+// Metadata (timestamps, source IDs, priorities) is kept in a parallel ring, so element types don't have to be wrapped
+// in a struct.
+type Tagged[T, M any] struct {
+	buf  RingBuffer[T]
+	meta RingBuffer[M]
+}
+
+// Create a new buffer which can store capacity elements with their metadata.
+func NewTagged[T, M any](capacity int) Tagged[T, M] {
+	return Tagged[T, M]{
+		buf:  New[T](capacity),
+		meta: New[M](capacity),
+	}
+}
+
+// How many elements a buffer can store?
+func (b Tagged[T, M]) Cap() int {
+	return b.buf.Cap()
+}
+
+// How many elements are currently stored in the buffer?
+func (b Tagged[T, M]) Len() int {
+	return b.buf.Len()
+}
+
+// Push a new element with its metadata to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *Tagged[T, M]) Push(v T, m M) bool {
+	if !b.buf.Push(v) {
+		return false
+	}
+	b.meta.Push(m)
+	return true
+}
+
+// Try to pop an element with its metadata from the buffer.
+//
+// Returns the popped element, its metadata and true on success. Returns default values and false if there were no
+// elements in the buffer.
+func (b *Tagged[T, M]) Pop() (T, M, bool) {
+	v, ok := b.buf.Pop()
+	m, _ := b.meta.Pop()
+	return v, m, ok
+}
@@ -0,0 +1,47 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTagged(t *testing.T) {
+	assert := assert.New(t)
+
+	eq3 := func(v int, m string, ok bool) func(expectedV int, expectedM string, expectedOk bool) {
+		return func(expectedV int, expectedM string, expectedOk bool) {
+			assert.Equal(expectedOk, ok)
+			assert.Equal(expectedV, v)
+			assert.Equal(expectedM, m)
+		}
+	}
+
+	{
+		var b ringbuffer.Tagged[int, string]
+		assert.Equal(false, b.Push(1, "a"))
+		eq3(b.Pop())(0, "", false)
+	}
+
+	b := ringbuffer.NewTagged[int, string](2)
+	assert.Equal(2, b.Cap())
+	assert.Equal(true, b.Push(1, "a"))
+	assert.Equal(true, b.Push(2, "b"))
+	assert.Equal(false, b.Push(3, "c"))
+	assert.Equal(2, b.Len())
+	eq3(b.Pop())(1, "a", true)
+	assert.Equal(true, b.Push(3, "c"))
+	eq3(b.Pop())(2, "b", true)
+	eq3(b.Pop())(3, "c", true)
+	eq3(b.Pop())(0, "", false)
+}
+
+func ExampleTagged() {
+	type source int
+	b := ringbuffer.NewTagged[string, source](5)
+	b.Push("hello", 7)
+	v, src, _ := b.Pop()
+	fmt.Println(v, src)
+	// Output: hello 7
+}
@@ -0,0 +1,80 @@
+package ringbuffer
+
+import (
+	"time"
+)
+
+// Fixed length FIFO ring buffer which evicts elements older than a time to live.
+//
+// Elements are timestamped on push. Expired elements are evicted lazily, whenever the buffer is accessed, in addition
+// to the capacity bound on pushes. Elements expire in FIFO order, so eviction only ever looks at the oldest ones.
+type TimedRing[T any] struct {
+	buf     Tagged[T, time.Time]
+	ttl     time.Duration
+	clock   Clock
+	expired uint64
+}
+
+// Create a new buffer which can store capacity elements, each for at most ttl.
+func NewTimedRing[T any](capacity int, ttl time.Duration) TimedRing[T] {
+	return TimedRing[T]{
+		buf: NewTagged[T, time.Time](capacity),
+		ttl: ttl,
+	}
+}
+
+// Use the given clock instead of the system clock. Nil restores the system clock.
+func (b *TimedRing[T]) SetClock(c Clock) {
+	b.clock = c
+}
+
+// How many elements a buffer can store?
+func (b *TimedRing[T]) Cap() int {
+	return b.buf.Cap()
+}
+
+// How many unexpired elements are currently stored in the buffer?
+func (b *TimedRing[T]) Len() int {
+	b.expire()
+	return b.buf.Len()
+}
+
+// How many elements were evicted because they expired so far?
+func (b *TimedRing[T]) Expired() uint64 {
+	b.expire()
+	return b.expired
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space after evicting expired elements and push failed.
+func (b *TimedRing[T]) Push(v T) bool {
+	b.expire()
+	return b.buf.Push(v, clockNow(b.clock))
+}
+
+// Try to pop the oldest unexpired element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *TimedRing[T]) Pop() (T, bool) {
+	b.expire()
+	v, _, ok := b.buf.Pop()
+	return v, ok
+}
+
+// Look at the oldest unexpired element without removing it from the buffer.
+//
+// Returns the oldest element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *TimedRing[T]) Peek() (T, bool) {
+	b.expire()
+	return b.buf.buf.Peek()
+}
+
+// Evict elements older than the time to live.
+func (b *TimedRing[T]) expire() {
+	now := clockNow(b.clock)
+	for b.buf.Len() > 0 && now.Sub(*b.buf.meta.slot(0)) > b.ttl {
+		b.buf.Pop()
+		b.expired++
+	}
+}
@@ -0,0 +1,65 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTimedRing(t *testing.T) {
+	assert := assert.New(t)
+
+	{
+		var b ringbuffer.TimedRing[int]
+		assert.Equal(false, b.Push(1))
+		_, ok := b.Pop()
+		assert.Equal(false, ok)
+	}
+
+	clock := ringbuffer.NewManualClock(time.Unix(1000, 0))
+	b := ringbuffer.NewTimedRing[int](3, 10*time.Second)
+	b.SetClock(clock)
+	assert.Equal(3, b.Cap())
+
+	b.Push(1)
+	clock.Advance(5 * time.Second)
+	b.Push(2)
+	b.Push(3)
+	assert.Equal(false, b.Push(4))
+
+	// exactly ttl old is still alive
+	clock.Advance(5 * time.Second)
+	assert.Equal(3, b.Len())
+	v, _ := b.Peek()
+	assert.Equal(1, v)
+
+	// expired elements make room for pushes
+	clock.Advance(time.Second)
+	assert.Equal(true, b.Push(4))
+	assert.Equal(uint64(1), b.Expired())
+	v, _ = b.Pop()
+	assert.Equal(2, v)
+
+	clock.Advance(5 * time.Second)
+	assert.Equal(1, b.Len())
+	v, _ = b.Pop()
+	assert.Equal(4, v)
+	assert.Equal(uint64(2), b.Expired())
+	_, ok := b.Peek()
+	assert.Equal(false, ok)
+}
+
+func ExampleTimedRing() {
+	clock := ringbuffer.NewManualClock(time.Unix(0, 0))
+	b := ringbuffer.NewTimedRing[string](8, time.Minute)
+	b.SetClock(clock)
+	b.Push("stale")
+	clock.Advance(50 * time.Second)
+	b.Push("fresh")
+	clock.Advance(20 * time.Second)
+	v, _ := b.Pop()
+	fmt.Println(v, b.Expired())
+	// Output: fresh 1
+}
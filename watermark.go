@@ -0,0 +1,71 @@
+package ringbuffer
+
+// Occupancy thresholds with notification callbacks.
+//
+// OnHigh is called when the number of elements rises to High or above. OnLow is called when it afterwards falls to Low
+// or below. Callbacks receive the current number of elements. Each callback fires once per crossing, the gap between
+// High and Low acts as hysteresis. Nil callbacks are ignored.
+type Watermarks struct {
+	High   int
+	Low    int
+	OnHigh func(n int)
+	OnLow  func(n int)
+}
+
+// Fixed length FIFO ring buffer which notifies about occupancy crossing watermarks.
+type Watermarked[T any] struct {
+	buf   RingBuffer[T]
+	marks Watermarks
+	above bool
+}
+
+// Create a new buffer which can store capacity elements and reports crossings of the given watermarks.
+func NewWatermarked[T any](capacity int, marks Watermarks) Watermarked[T] {
+	return Watermarked[T]{
+		buf:   New[T](capacity),
+		marks: marks,
+	}
+}
+
+// How many elements a buffer can store?
+func (b Watermarked[T]) Cap() int {
+	return b.buf.Cap()
+}
+
+// How many elements are currently stored in the buffer?
+func (b Watermarked[T]) Len() int {
+	return b.buf.Len()
+}
+
+// Push a new element to the buffer.
+//
+// Returns true on success. Returns false if there is no free space and push failed.
+func (b *Watermarked[T]) Push(v T) bool {
+	if !b.buf.Push(v) {
+		return false
+	}
+	if n := b.buf.Len(); !b.above && n >= b.marks.High {
+		b.above = true
+		if b.marks.OnHigh != nil {
+			b.marks.OnHigh(n)
+		}
+	}
+	return true
+}
+
+// Try to pop an element from the buffer.
+//
+// Returns the popped element and true on success. Returns default value and false if there were no elements in the buffer.
+func (b *Watermarked[T]) Pop() (T, bool) {
+	v, ok := b.buf.Pop()
+	if !ok {
+		return v, false
+	}
+	if n := b.buf.Len(); b.above && n <= b.marks.Low {
+		b.above = false
+		if b.marks.OnLow != nil {
+			b.marks.OnLow(n)
+		}
+	}
+	return v, true
+}
@@ -0,0 +1,68 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWatermarked(t *testing.T) {
+	assert := assert.New(t)
+
+	var events []string
+	b := ringbuffer.NewWatermarked[int](5, ringbuffer.Watermarks{
+		High:   4,
+		Low:    2,
+		OnHigh: func(n int) { events = append(events, fmt.Sprintf("high %d", n)) },
+		OnLow:  func(n int) { events = append(events, fmt.Sprintf("low %d", n)) },
+	})
+	assert.Equal(5, b.Cap())
+	for i := 0; i < 5; i++ {
+		assert.Equal(true, b.Push(i))
+	}
+	assert.Equal(false, b.Push(5))
+	assert.Equal([]string{"high 4"}, events)
+
+	b.Pop()
+	b.Pop()
+	assert.Equal([]string{"high 4"}, events)
+	b.Push(5)
+	b.Pop()
+	assert.Equal([]string{"high 4"}, events)
+	b.Pop()
+	assert.Equal([]string{"high 4", "low 2"}, events)
+	b.Pop()
+	b.Pop()
+	b.Push(6)
+	b.Push(7)
+	b.Push(8)
+	b.Push(9)
+	assert.Equal([]string{"high 4", "low 2", "high 4"}, events)
+	assert.Equal(4, b.Len())
+
+	// nil callbacks are fine
+	b2 := ringbuffer.NewWatermarked[int](2, ringbuffer.Watermarks{High: 1, Low: 0})
+	assert.Equal(true, b2.Push(1))
+	v, ok := b2.Pop()
+	assert.Equal(1, v)
+	assert.Equal(true, ok)
+}
+
+func ExampleWatermarked() {
+	b := ringbuffer.NewWatermarked[int](10, ringbuffer.Watermarks{
+		High:   8,
+		Low:    5,
+		OnHigh: func(n int) { fmt.Printf("throttle at %d\n", n) },
+		OnLow:  func(n int) { fmt.Printf("resume at %d\n", n) },
+	})
+	for i := 0; i < 8; i++ {
+		b.Push(i)
+	}
+	for i := 0; i < 3; i++ {
+		b.Pop()
+	}
+	// Output:
+	// throttle at 8
+	// resume at 5
+}
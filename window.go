@@ -0,0 +1,72 @@
+package ringbuffer
+
+// Aggregate over the last N pushed elements, maintained with user-supplied fold functions.
+//
+// Fold adds an element to the aggregate. Unfold removes an element evicted from the window, it is optional: for
+// aggregates which can't be undone (minimum, maximum) pass nil, and the aggregate is recomputed from the stored
+// elements the next time it is requested after an eviction. The aggregate of an empty window is the zero value of A.
+type Window[T, A any] struct {
+	buf    RingBuffer[T]
+	agg    A
+	dirty  bool
+	fold   func(A, T) A
+	unfold func(A, T) A
+}
+
+// Create a new window over the last n elements.
+func NewWindow[T, A any](n int, fold, unfold func(A, T) A) Window[T, A] {
+	return Window[T, A]{buf: New[T](n), fold: fold, unfold: unfold}
+}
+
+// How many elements a window covers?
+func (w Window[T, A]) Cap() int {
+	return w.buf.Cap()
+}
+
+// How many elements are currently in the window?
+func (w Window[T, A]) Len() int {
+	return w.buf.Len()
+}
+
+// Push a new element to the window, evicting the oldest one if the window is full. Does nothing for zero length
+// windows.
+func (w *Window[T, A]) Push(v T) {
+	if w.buf.Cap() == 0 {
+		return
+	}
+	if w.buf.IsFull() {
+		old, _ := w.buf.Pop()
+		if w.unfold == nil {
+			w.dirty = true
+		} else if !w.dirty {
+			w.agg = w.unfold(w.agg, old)
+		}
+	}
+	w.buf.Push(v)
+	if !w.dirty {
+		w.agg = w.fold(w.agg, v)
+	}
+}
+
+// Get the aggregate over the elements currently in the window.
+func (w *Window[T, A]) Aggregate() A {
+	if w.dirty {
+		var agg A
+		for v := range w.buf.Values() {
+			agg = w.fold(agg, v)
+		}
+		w.agg = agg
+		w.dirty = false
+	}
+	return w.agg
+}
+
+// Remove all elements from the window.
+func (w *Window[T, A]) Reset() {
+	var agg A
+	for w.buf.Len() > 0 {
+		w.buf.Pop()
+	}
+	w.agg = agg
+	w.dirty = false
+}
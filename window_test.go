@@ -0,0 +1,65 @@
+package ringbuffer_test
+
+import (
+	"fmt"
+	"github.com/nsf/ringbuffer"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	add := func(a, v int) int { return a + v }
+	sub := func(a, v int) int { return a - v }
+
+	{
+		w := ringbuffer.NewWindow(0, add, sub)
+		w.Push(1)
+		assert.Equal(0, w.Len())
+		assert.Equal(0, w.Aggregate())
+	}
+
+	sum := ringbuffer.NewWindow(3, add, sub)
+	assert.Equal(3, sum.Cap())
+	folds := 0
+	peak := ringbuffer.NewWindow(3, func(a, v int) int {
+		folds++
+		return max(a, v)
+	}, nil)
+	for _, v := range []int{5, 1, 2} {
+		sum.Push(v)
+		peak.Push(v)
+	}
+	assert.Equal(8, sum.Aggregate())
+	assert.Equal(5, peak.Aggregate())
+
+	// evicting 5 undoes it for the sum, the maximum is recomputed once on request
+	sum.Push(4)
+	peak.Push(4)
+	peak.Push(3)
+	assert.Equal(3, sum.Len())
+	assert.Equal(7, sum.Aggregate())
+	folds = 0
+	assert.Equal(4, peak.Aggregate())
+	assert.Equal(3, folds)
+	assert.Equal(4, peak.Aggregate())
+	assert.Equal(3, folds)
+
+	sum.Reset()
+	assert.Equal(0, sum.Len())
+	assert.Equal(0, sum.Aggregate())
+	sum.Push(2)
+	assert.Equal(2, sum.Aggregate())
+}
+
+func ExampleWindow() {
+	sizes := ringbuffer.NewWindow(100,
+		func(a, v int) int { return a + v },
+		func(a, v int) int { return a - v })
+	for i := 1; i <= 150; i++ {
+		sizes.Push(i)
+	}
+	fmt.Println(sizes.Aggregate())
+	// Output: 10050
+}